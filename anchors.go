@@ -0,0 +1,122 @@
+package markparsr
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+var htmlAnchorRe = regexp.MustCompile(`(?i)<[a-z][^>]*?\s(?:name|id)\s*=\s*["']([^"']+)["']`)
+
+type AnchorValidator struct {
+	content *MarkdownContent
+}
+
+func NewAnchorValidator(content *MarkdownContent) *AnchorValidator {
+	return &AnchorValidator{content: content}
+}
+
+func (av *AnchorValidator) Validate() []error {
+	return av.ValidateContext(context.Background())
+}
+
+func (av *AnchorValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	targets := av.content.AnchorTargets()
+
+	var errs []error
+	seen := make(map[string]bool)
+	for _, fragment := range av.content.FragmentLinks() {
+		if seen[fragment] {
+			continue
+		}
+		seen[fragment] = true
+
+		if !targets[fragment] {
+			errs = append(errs, newFinding(RuleBrokenAnchor, "#"+fragment, "anchor link target not found: #%s", fragment).at(av.content.linkLine("#"+fragment)))
+		}
+	}
+	return errs
+}
+
+func (mc *MarkdownContent) FragmentLinks() []string {
+	var fragments []string
+	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		if link, ok := node.(*ast.Link); ok {
+			if fragment, ok := strings.CutPrefix(string(link.Destination), "#"); ok && fragment != "" {
+				fragments = append(fragments, decodeFragment(fragment))
+			}
+		}
+		return ast.GoToNext
+	})
+	return fragments
+}
+
+func (mc *MarkdownContent) AnchorTargets() map[string]bool {
+	targets := make(map[string]bool)
+	slugCounts := make(map[string]int)
+
+	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		switch n := node.(type) {
+		case *ast.Heading:
+			if n.HeadingID != "" {
+				targets[n.HeadingID] = true
+			}
+			slug := headingSlug(mc.extractText(n))
+			if count := slugCounts[slug]; count > 0 {
+				targets[slug+"-"+strconv.Itoa(count)] = true
+			} else {
+				targets[slug] = true
+			}
+			slugCounts[slug]++
+		case *ast.HTMLSpan:
+			addHTMLAnchors(targets, n.Literal)
+		case *ast.HTMLBlock:
+			addHTMLAnchors(targets, n.Literal)
+		}
+		return ast.GoToNext
+	})
+
+	return targets
+}
+
+func addHTMLAnchors(targets map[string]bool, literal []byte) {
+	for _, match := range htmlAnchorRe.FindAllSubmatch(literal, -1) {
+		targets[string(match[1])] = true
+	}
+}
+
+func headingSlug(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+func decodeFragment(fragment string) string {
+	fragment = strings.ReplaceAll(fragment, `\_`, "_")
+	if decoded, err := url.PathUnescape(fragment); err == nil {
+		return decoded
+	}
+	return fragment
+}
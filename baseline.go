@@ -0,0 +1,102 @@
+package markparsr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type BaselineEntry struct {
+	Rule   string `json:"rule"`
+	Item   string `json:"item"`
+	Module string `json:"module"`
+}
+
+type Baseline struct {
+	Findings []BaselineEntry `json:"findings"`
+}
+
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &Baseline{}, nil
+		}
+		return nil, fmt.Errorf("failed to read baseline %s: %w", path, err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+
+	return &baseline, nil
+}
+
+func (b *Baseline) Save(path string) error {
+	slices.SortFunc(b.Findings, func(a, c BaselineEntry) int {
+		return strings.Compare(a.Module+"\x00"+a.Rule+"\x00"+a.Item, c.Module+"\x00"+c.Rule+"\x00"+c.Item)
+	})
+	b.Findings = slices.Compact(b.Findings)
+	if b.Findings == nil {
+		b.Findings = []BaselineEntry{}
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+func (b *Baseline) Contains(module string, err error) bool {
+	rule, item := findingKey(err)
+	return slices.Contains(b.Findings, BaselineEntry{Rule: rule, Item: item, Module: module})
+}
+
+func (b *Baseline) Filter(module string, errs []error) []error {
+	var filtered []error
+	for _, err := range errs {
+		if !b.Contains(module, err) {
+			filtered = append(filtered, err)
+		}
+	}
+	return filtered
+}
+
+func (b *Baseline) Update(module string, errs []error) {
+	b.Findings = slices.DeleteFunc(b.Findings, func(entry BaselineEntry) bool {
+		return entry.Module == module
+	})
+
+	for _, err := range errs {
+		rule, item := findingKey(err)
+		b.Findings = append(b.Findings, BaselineEntry{Rule: rule, Item: item, Module: module})
+	}
+}
+
+func baselineModuleKey(baselinePath, modulePath string) string {
+	if !filepath.IsAbs(modulePath) {
+		return filepath.ToSlash(modulePath)
+	}
+
+	absBaseline, err := filepath.Abs(baselinePath)
+	if err != nil {
+		return filepath.ToSlash(modulePath)
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(absBaseline), modulePath)
+	if err != nil {
+		return filepath.ToSlash(modulePath)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -1,5 +1,7 @@
 package markparsr
 
+import "context"
+
 type TerraformDefinitionValidator struct {
 	markdown  *MarkdownContent
 	terraform *TerraformContent
@@ -13,7 +15,15 @@ func NewTerraformDefinitionValidator(markdown *MarkdownContent, terraform *Terra
 }
 
 func (tdv *TerraformDefinitionValidator) Validate() []error {
-	tfResources, tfDataSources, err := tdv.terraform.ExtractResourcesAndDataSources()
+	return tdv.ValidateContext(context.Background())
+}
+
+func (tdv *TerraformDefinitionValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	tfResources, tfDataSources, err := tdv.terraform.ExtractResourcesAndDataSourcesContext(ctx)
 	if err != nil {
 		return []error{err}
 	}
@@ -25,8 +35,8 @@ func (tdv *TerraformDefinitionValidator) Validate() []error {
 		collector.Add(mdErr)
 	}
 	if tdv.markdown.HasSection("Resources") || len(readmeResources) > 0 || len(readmeDataSources) > 0 {
-		collector.AddMany(compareTerraformAndMarkdown(tfResources, readmeResources, "Resources"))
-		collector.AddMany(compareTerraformAndMarkdown(tfDataSources, readmeDataSources, "Data Sources"))
+		collector.AddMany(tdv.markdown.locateItems(compareTerraformAndMarkdown(tfResources, readmeResources, "Resources")))
+		collector.AddMany(tdv.markdown.locateItems(compareTerraformAndMarkdown(tfDataSources, readmeDataSources, "Data Sources")))
 	}
 
 	return collector.Errors()
@@ -0,0 +1,290 @@
+package markparsr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const (
+	tfDocsBegin = "<!-- BEGIN_TF_DOCS -->"
+	tfDocsEnd   = "<!-- END_TF_DOCS -->"
+)
+
+func (tc *TerraformContent) GenerateDocs() (string, error) {
+	return tc.GenerateDocsContext(context.Background())
+}
+
+func (tc *TerraformContent) GenerateDocsContext(ctx context.Context) (string, error) {
+	var sections []string
+
+	requirements, err := tc.ExtractProviderRequirementsContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	requiredVersion, err := tc.ExtractRequiredVersionContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	resources, dataSources, err := tc.ExtractResourcesAndDataSourcesContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	variables, err := tc.ExtractModuleBlocksContext(ctx, "variable")
+	if err != nil {
+		return "", err
+	}
+	outputs, err := tc.ExtractModuleBlocksContext(ctx, "output")
+	if err != nil {
+		return "", err
+	}
+
+	resources, dataSources = qualifiedNames(resources), qualifiedNames(dataSources)
+	providers := usedProviders(requirements, resources, dataSources)
+
+	var items []string
+	if requiredVersion != "" {
+		items = append(items, docsListItem("requirement", "terraform", requiredVersion))
+	}
+	for _, name := range slices.Sorted(maps.Keys(requirements)) {
+		items = append(items, docsListItem("requirement", name, requirements[name].Version))
+	}
+	sections = append(sections, docsSection("Requirements", "The following requirements are needed by this module:", "No requirements.", items...))
+
+	items = nil
+	for _, name := range providers {
+		items = append(items, docsListItem("provider", name, requirements[name].Version))
+	}
+	sections = append(sections, docsSection("Providers", "The following providers are used by this module:", "No providers.", items...))
+
+	var links []string
+	for _, name := range resources {
+		links = append(links, fmt.Sprintf("- [%s](%s) (resource)", name, registryDocsURL(name, "resources", requirements)))
+	}
+	for _, name := range dataSources {
+		links = append(links, fmt.Sprintf("- [%s](%s) (data source)", name, registryDocsURL(name, "data-sources", requirements)))
+	}
+	if len(links) > 0 {
+		links = []string{strings.Join(links, "\n")}
+	}
+	sections = append(sections, docsSection("Resources", "The following resources are used by this module:", "No resources.", links...))
+
+	var required, optional []string
+	slices.SortFunc(variables, func(a, b *ModuleBlock) int { return strings.Compare(a.Name, b.Name) })
+	for _, variable := range variables {
+		if _, ok := variable.Attributes["default"]; ok {
+			optional = append(optional, docsInput(variable))
+		} else {
+			required = append(required, docsInput(variable))
+		}
+	}
+	sections = append(sections,
+		docsSection("Required Inputs", "The following input variables are required:", "No required inputs.", required...),
+		docsSection("Optional Inputs", "The following input variables are optional (have default values):", "No optional inputs.", optional...),
+	)
+
+	items = nil
+	slices.SortFunc(outputs, func(a, b *ModuleBlock) int { return strings.Compare(a.Name, b.Name) })
+	for _, output := range outputs {
+		items = append(items, docsOutput(output))
+	}
+	sections = append(sections, docsSection("Outputs", "The following outputs are exported:", "No outputs.", items...))
+
+	return tfDocsBegin + "\n" + strings.Join(sections, "\n\n") + "\n" + tfDocsEnd, nil
+}
+
+func InjectDocs(readme, docs string) (string, error) {
+	src := NewMarkdownContent(readme, FormatDocument, nil).source
+	switch {
+	case len(src.docsBegins) == 0 && len(src.docsEnds) == 0:
+		return strings.TrimRight(readme, "\n") + "\n\n" + docs + "\n", nil
+	case len(src.docsBegins) != 1 || len(src.docsEnds) != 1:
+		return "", fmt.Errorf("expected one %s and one %s marker, found %d and %d", tfDocsBegin, tfDocsEnd, len(src.docsBegins), len(src.docsEnds))
+	case src.docsEnds[0] < src.docsBegins[0]:
+		return "", fmt.Errorf("%s marker on line %d comes before %s on line %d", tfDocsEnd, src.lineAt(src.docsEnds[0]), tfDocsBegin, src.lineAt(src.docsBegins[0]))
+	}
+	return readme[:src.docsBegins[0]] + docs + readme[src.docsEnds[0]+len(tfDocsEnd):], nil
+}
+
+func writeDocs(ctx context.Context, fsys fs.FS, readmePath, readme string, markdown *MarkdownContent, terraform *TerraformContent, dryRun bool) (string, error) {
+	docs, err := terraform.GenerateDocsContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	updated, err := InjectDocs(readme, relevelHeadings(docs, markdown.sectionLevel, markdown.itemLevel))
+	if err != nil {
+		return "", err
+	}
+	if updated == readme {
+		return readme, nil
+	}
+
+	if err := writeReadme(fsys, readmePath, readme, updated, dryRun); err != nil {
+		return "", err
+	}
+	if dryRun {
+		return readme, nil
+	}
+	if os.Getenv("VERBOSE") == "true" {
+		fmt.Printf("Regenerated terraform-docs block in %s\n", readmePath)
+	}
+	return updated, nil
+}
+
+func qualifiedNames(names []string) []string {
+	var qualified []string
+	for _, name := range names {
+		if strings.Contains(name, ".") && !slices.Contains(qualified, name) {
+			qualified = append(qualified, name)
+		}
+	}
+	slices.Sort(qualified)
+	return qualified
+}
+
+func usedProviders(requirements map[string]ProviderRequirement, resourceLists ...[]string) []string {
+	providers := slices.Collect(maps.Keys(requirements))
+	for _, names := range resourceLists {
+		for _, name := range names {
+			provider, _, _ := strings.Cut(name, "_")
+			if !slices.Contains(providers, provider) {
+				providers = append(providers, provider)
+			}
+		}
+	}
+	slices.Sort(providers)
+	return providers
+}
+
+func registryDocsURL(name, kind string, requirements map[string]ProviderRequirement) string {
+	resourceType, _, _ := strings.Cut(name, ".")
+	provider, slug, _ := strings.Cut(resourceType, "_")
+	namespace := requirements[provider].Namespace()
+	if namespace == "" {
+		namespace = "hashicorp"
+	}
+	return fmt.Sprintf("https://registry.terraform.io/providers/%s/%s/latest/docs/%s/%s", namespace, provider, kind, slug)
+}
+
+func docsSection(title, intro, empty string, items ...string) string {
+	if len(items) == 0 {
+		return "## " + title + "\n\n" + empty
+	}
+	return "## " + title + "\n\n" + intro + "\n\n" + strings.Join(items, "\n\n")
+}
+
+func relevelHeadings(text string, sectionLevel, itemLevel int) string {
+	if sectionLevel == 2 && itemLevel == 3 {
+		return text
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, heading := range NewMarkdownContent(text, FormatDocument, nil).source.headings {
+		if !strings.HasPrefix(text[heading.start:], strings.Repeat("#", heading.level)) {
+			continue
+		}
+		level := heading.level
+		switch level {
+		case 2:
+			level = sectionLevel
+		case 3:
+			level = itemLevel
+		default:
+			continue
+		}
+		sb.WriteString(text[last:heading.start])
+		sb.WriteString(strings.Repeat("#", level))
+		last = heading.start + heading.level
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+func docsListItem(kind, name, version string) string {
+	item := fmt.Sprintf(`- <a name="%s_%s"></a> [%s](#%s)`, kind, name, escapeDocs(name), escapeDocs(kind+"_"+name))
+	if version != "" {
+		item += " (" + version + ")"
+	}
+	return item
+}
+
+func docsHeading(kind, name string) string {
+	return fmt.Sprintf(`### <a name="%s_%s"></a> [%s](#%s)`, kind, name, escapeDocs(name), escapeDocs(kind+"_"+name))
+}
+
+func docsInput(variable *ModuleBlock) string {
+	item := []string{
+		docsHeading("input", variable.Name),
+		"Description: " + docsDescription(variable),
+		docsValue("Type", "hcl", docsType(variable)),
+	}
+	if def, ok := variable.Attributes["default"]; ok {
+		item = append(item, docsValue("Default", "json", docsDefault(def)))
+	}
+	return strings.Join(item, "\n\n")
+}
+
+func docsOutput(output *ModuleBlock) string {
+	return docsHeading("output", output.Name) + "\n\nDescription: " + docsDescription(output)
+}
+
+func docsValue(label, lang, value string) string {
+	if strings.Contains(value, "\n") {
+		return label + ":\n\n```" + lang + "\n" + value + "\n```"
+	}
+	return label + ": `" + value + "`"
+}
+
+func docsDescription(block *ModuleBlock) string {
+	attr, ok := block.Attributes["description"]
+	if !ok {
+		return "n/a"
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return attr.Source
+	}
+	return strings.TrimSpace(value.AsString())
+}
+
+func docsType(block *ModuleBlock) string {
+	if attr, ok := block.Attributes["type"]; ok {
+		return attr.Source
+	}
+	return "any"
+}
+
+func docsDefault(attr *ModuleAttribute) string {
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() {
+		return attr.Source
+	}
+	if value.IsNull() {
+		return "null"
+	}
+
+	data, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return attr.Source
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return indented.String()
+}
+
+func escapeDocs(text string) string {
+	return strings.ReplaceAll(text, "_", `\_`)
+}
@@ -0,0 +1,158 @@
+package markparsr
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+var generatedSections = []string{
+	"Requirements", "Providers", "Modules", "Resources", "Inputs", "Required Inputs", "Optional Inputs", "Outputs",
+}
+
+type DocsBlockValidator struct {
+	content *MarkdownContent
+}
+
+func NewDocsBlockValidator(content *MarkdownContent) *DocsBlockValidator {
+	return &DocsBlockValidator{content: content}
+}
+
+func (dv *DocsBlockValidator) Validate() []error {
+	return dv.ValidateContext(context.Background())
+}
+
+func (dv *DocsBlockValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	src := dv.content.source
+	if len(src.docsBegins) == 0 && len(src.docsEnds) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for i, begin := range src.docsBegins {
+		if i > 0 {
+			errs = append(errs, newFinding(RuleDocsBlock, tfDocsBegin, "duplicate %s marker", tfDocsBegin).at(src.lineAt(begin)))
+		}
+	}
+	for i, end := range src.docsEnds {
+		if i > 0 {
+			errs = append(errs, newFinding(RuleDocsBlock, tfDocsEnd, "duplicate %s marker", tfDocsEnd).at(src.lineAt(end)))
+		}
+	}
+
+	if _, _, ok := dv.content.docsBlock(); !ok {
+		switch {
+		case len(src.docsBegins) == 0:
+			errs = append(errs, newFinding(RuleDocsBlock, tfDocsBegin, "%s marker without %s", tfDocsEnd, tfDocsBegin).at(src.lineAt(src.docsEnds[0])))
+		default:
+			errs = append(errs, newFinding(RuleDocsBlock, tfDocsEnd, "%s marker is not closed by %s", tfDocsBegin, tfDocsEnd).at(src.lineAt(src.docsBegins[0])))
+		}
+		return errs
+	}
+
+	for _, heading := range dv.content.sectionHeadings {
+		text := dv.content.sectionName(heading)
+		if text == "" {
+			continue
+		}
+		line := dv.content.headingLine(heading)
+
+		inside := dv.content.inDocsBlock(heading)
+		switch {
+		case !inside && isGeneratedSection(text):
+			errs = append(errs, newFinding(RuleDocsBlock, text, "section '%s' belongs inside the terraform-docs block", text).at(line))
+		case inside && !resemblesGeneratedSection(text):
+			errs = append(errs, newFinding(RuleDocsBlock, text, "section '%s' is inside the terraform-docs block and will be overwritten when the docs are regenerated", text).at(line))
+		}
+	}
+
+	return errs
+}
+
+func resemblesGeneratedSection(name string) bool {
+	return slices.ContainsFunc(generatedSections, func(section string) bool {
+		return isSimilarSection(strings.TrimSpace(name), section)
+	})
+}
+
+func isGeneratedSection(name string) bool {
+	return slices.ContainsFunc(generatedSections, func(section string) bool {
+		return strings.EqualFold(section, strings.TrimSpace(name))
+	})
+}
+
+func (mc *MarkdownContent) docsBlock() (int, int, bool) {
+	if len(mc.source.docsBegins) == 0 {
+		return 0, 0, false
+	}
+	begin := mc.source.docsBegins[0]
+	for _, end := range mc.source.docsEnds {
+		if end > begin {
+			return begin, end, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (mc *MarkdownContent) inDocsBlock(heading *ast.Heading) bool {
+	begin, end, ok := mc.docsBlock()
+	src, found := mc.headingSources[heading]
+	return ok && found && src.start > begin && src.start < end
+}
+
+func (mc *MarkdownContent) generatedData() string {
+	if begin, end, ok := mc.docsBlock(); ok {
+		return mc.data[begin:end]
+	}
+	return mc.data
+}
+
+func (mc *MarkdownContent) generatedSectionNames() []string {
+	if _, _, ok := mc.docsBlock(); !ok {
+		return mc.GetAllSections()
+	}
+
+	var names []string
+	for _, heading := range mc.sectionHeadings {
+		if !mc.inDocsBlock(heading) {
+			continue
+		}
+		if text := mc.sectionName(heading); text != "" {
+			names = append(names, text)
+		}
+	}
+	return names
+}
+
+func (mc *MarkdownContent) headingNames(level int, generated bool) []string {
+	if level == mc.sectionLevel {
+		if generated {
+			return mc.generatedSectionNames()
+		}
+		return mc.GetAllSections()
+	}
+
+	_, _, scoped := mc.docsBlock()
+	scoped = scoped && generated
+
+	var names []string
+	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		heading, ok := node.(*ast.Heading)
+		if !entering || !ok {
+			return ast.GoToNext
+		}
+		if heading.Level == level && (!scoped || mc.inDocsBlock(heading)) {
+			if text := strings.TrimSpace(mc.extractText(heading)); text != "" {
+				names = append(names, text)
+			}
+		}
+		return ast.SkipChildren
+	})
+	return names
+}
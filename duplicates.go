@@ -0,0 +1,118 @@
+package markparsr
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+type DuplicateValidator struct {
+	content *MarkdownContent
+}
+
+type documentedItem struct {
+	name string
+	line int
+}
+
+func NewDuplicateValidator(content *MarkdownContent) *DuplicateValidator {
+	return &DuplicateValidator{content: content}
+}
+
+func (dv *DuplicateValidator) Validate() []error {
+	return dv.ValidateContext(context.Background())
+}
+
+func (dv *DuplicateValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	firstSection := make(map[string]int)
+	for _, heading := range dv.content.sectionHeadings {
+		text := dv.content.sectionName(heading)
+		if text == "" {
+			continue
+		}
+		line := dv.content.headingLine(heading)
+
+		key := strings.ToLower(text)
+		if first, ok := firstSection[key]; ok {
+			errs = append(errs, newFinding(RuleDuplicate, text,
+				"section '%s' is duplicated (first at line %d)", text, first).at(line))
+		} else {
+			firstSection[key] = line
+		}
+
+		if !isGeneratedSection(text) {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, item := range dv.content.documentedItems(heading) {
+			key := strings.ToLower(item.name)
+			if seen[key] {
+				errs = append(errs, newFinding(RuleDuplicate, text+"/"+item.name,
+					"item '%s' is documented more than once in section '%s'", item.name, text).at(item.line))
+			}
+			seen[key] = true
+		}
+	}
+
+	if dv.content.format == FormatTable {
+		return errs
+	}
+
+	required := make(map[string]bool)
+	for _, heading := range dv.content.matchSectionHeadings("Required Inputs") {
+		for _, item := range dv.content.documentedItems(heading) {
+			required[strings.ToLower(item.name)] = true
+		}
+	}
+	for _, heading := range dv.content.matchSectionHeadings("Optional Inputs") {
+		for _, item := range dv.content.documentedItems(heading) {
+			if required[strings.ToLower(item.name)] {
+				errs = append(errs, newFinding(RuleDuplicate, "Inputs/"+item.name,
+					"input '%s' is documented in both Required Inputs and Optional Inputs", item.name).at(item.line))
+			}
+		}
+	}
+
+	return errs
+}
+
+func (mc *MarkdownContent) documentedItems(section *ast.Heading) []documentedItem {
+	var items []documentedItem
+	if mc.format == FormatTable {
+		line := mc.headingLine(section)
+		for node := getNextSibling(section); node != nil; node = getNextSibling(node) {
+			if h, ok := node.(*ast.Heading); ok && h.Level <= section.Level {
+				break
+			}
+			if table, ok := node.(*ast.Table); ok {
+				for _, row := range mc.tableRows(table) {
+					items = append(items, documentedItem{name: row.Name, line: line})
+				}
+			}
+		}
+		return items
+	}
+
+	for node := getNextSibling(section); node != nil; node = getNextSibling(node) {
+		h, ok := node.(*ast.Heading)
+		if !ok {
+			continue
+		}
+		if h.Level <= section.Level {
+			break
+		}
+		if h.Level != mc.itemLevel {
+			continue
+		}
+		if name, ok := mc.itemNameFromHeading(h); ok {
+			items = append(items, documentedItem{name: name, line: mc.headingLine(h)})
+		}
+	}
+	return items
+}
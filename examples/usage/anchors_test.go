@@ -0,0 +1,58 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestAnchorLinks(t *testing.T) {
+	content := markparsr.NewMarkdownContent(`# Module
+
+Jump to [name](#input\_name), [encoded](#input%5Ftags), [missing](#input\_location), [outputs](#Outputs), [id](#Output_ID) or [mixed](#Mixed_Case).
+
+## Example
+
+First example.
+
+## Example
+
+Second example, see [first](#example), [second](#example-1) and [third](#example-2).
+
+## What's new?
+
+See [this section](#whats-new) and [the custom id](#custom).
+
+## Renamed {#custom}
+
+### <a name="input_name"></a> [name](#input\_name)
+
+<a name="input_tags"></a>
+
+<a name="output_id"></a> <a id="Mixed_Case"></a>
+
+## Outputs
+`, markparsr.FormatDocument, nil)
+
+	var got []string
+	for _, err := range markparsr.NewAnchorValidator(content).Validate() {
+		var finding *markparsr.Finding
+		if !errors.As(err, &finding) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, fmt.Sprintf("%d %s", finding.Line, finding.Message))
+	}
+
+	want := []string{
+		"3 anchor link target not found: #input_location",
+		"3 anchor link target not found: #Outputs",
+		"3 anchor link target not found: #Output_ID",
+		"11 anchor link target not found: #example-2",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("findings = %q, want %q", got, want)
+	}
+}
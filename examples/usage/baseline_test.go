@@ -0,0 +1,70 @@
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestBaseline(t *testing.T) {
+	t.Setenv("BASELINE_PATH", "")
+	t.Setenv("UPDATE_BASELINE", "")
+
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte("# Module\n\n## Requirements\n\nNo requirements.\n")},
+		"module/main.tf":   {Data: []byte("")},
+	}
+
+	validate := func(opts ...markparsr.Option) []error {
+		t.Helper()
+		validator, err := markparsr.NewReadmeValidator(append([]markparsr.Option{
+			markparsr.WithFS(fsys),
+			markparsr.WithRelativeReadmePath("module/README.md"),
+		}, opts...)...)
+		if err != nil {
+			t.Fatalf("Failed to create validator: %v", err)
+		}
+		return validator.Validate()
+	}
+
+	known := validate()
+	if len(known) == 0 {
+		t.Fatal("expected findings without a baseline")
+	}
+
+	if errs := validate(markparsr.WithBaseline(baselinePath), markparsr.WithUpdateBaseline()); len(errs) != 0 {
+		t.Fatalf("updating the baseline reported %v", errs)
+	}
+	baseline, err := markparsr.LoadBaseline(baselinePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(baseline.Findings) != len(known) || baseline.Findings[0].Module != "module" {
+		t.Errorf("baseline = %+v, want %d entries for module", baseline.Findings, len(known))
+	}
+
+	if errs := validate(markparsr.WithBaseline(baselinePath)); len(errs) != 0 {
+		t.Errorf("baselined findings reported again: %v", errs)
+	}
+
+	t.Setenv("BASELINE_PATH", filepath.Join(t.TempDir(), "other.json"))
+	if errs := validate(markparsr.WithBaseline(baselinePath)); len(errs) != 0 {
+		t.Errorf("BASELINE_PATH overrode WithBaseline: %v", errs)
+	}
+	t.Setenv("BASELINE_PATH", "")
+
+	fsys["module/README.md"] = &fstest.MapFile{Data: []byte("# Module\n\n## Requirements\n\nNo requirements.\n\n## Requirements\n")}
+	errs := validate(markparsr.WithBaseline(baselinePath))
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "duplicated") {
+		t.Errorf("new findings = %v, want only the duplicated section", errs)
+	}
+
+	errs = validate(markparsr.WithUpdateBaseline())
+	if len(errs) == 0 || !strings.Contains(errs[len(errs)-1].Error(), "requires a baseline path") {
+		t.Errorf("updating without a baseline path = %v, want an error", errs)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestContextCancellation(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte("# Module\n")},
+		"module/main.tf":   {Data: []byte("resource \"azurerm_resource_group\" \"this\" {}\n")},
+		"module/terraform.tf": {Data: []byte(`terraform {
+  required_providers {
+    azurerm = {
+      source = "hashicorp/azurerm"
+    }
+  }
+}
+`)},
+	}
+	terraform, err := markparsr.NewTerraformContentFS(fsys, "module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := terraform.ExtractProviderRequirementsContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ExtractProviderRequirementsContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := terraform.GenerateDocsContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateDocsContext() error = %v, want context.Canceled", err)
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	if errs := validator.ValidateContext(ctx); len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("ValidateContext() = %v, want only context.Canceled", errs)
+	}
+}
+
+func TestURLTimeoutReported(t *testing.T) {
+	for _, env := range []string{"OFFLINE", "URL_CACHE_PATH"} {
+		t.Setenv(env, "")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(fmt.Sprintf("# Module\n\nSee the [docs](%s/docs).\n", server.URL))},
+	}
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+		markparsr.WithHTTPClient(server.Client()),
+		markparsr.WithURLRetries(0, time.Millisecond),
+		markparsr.WithHostConfig("127.0.0.1", markparsr.HostConfig{Timeout: 20 * time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	var timedOut bool
+	for _, err := range validator.Validate() {
+		var finding *markparsr.Finding
+		if errors.As(err, &finding) && finding.Rule == markparsr.RuleBrokenURL && errors.Is(err, context.DeadlineExceeded) {
+			timedOut = true
+		}
+	}
+	if !timedOut {
+		t.Error("expected a request timeout to be reported as a broken URL")
+	}
+}
@@ -0,0 +1,173 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestGenerateDocs(t *testing.T) {
+	terraform, err := markparsr.NewTerraformContent("../module")
+	if err != nil {
+		t.Fatalf("Failed to load terraform content: %v", err)
+	}
+
+	docs, err := terraform.GenerateDocs()
+	if err != nil {
+		t.Fatalf("Failed to generate docs: %v", err)
+	}
+
+	readme, err := os.ReadFile("../module/README.md")
+	if err != nil {
+		t.Fatalf("Failed to read README: %v", err)
+	}
+	if !strings.Contains(string(readme), docs) {
+		t.Errorf("generated docs do not match the terraform-docs block in the README:\n%s", docs)
+	}
+}
+
+func TestUpdateDocs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"README.md": `# Storage
+
+<!-- BEGIN_TF_DOCS -->
+## Outputs
+
+No outputs.
+<!-- END_TF_DOCS -->
+
+## Notes
+
+Hand-written notes stay in place.
+`,
+		"main.tf": `resource "azurerm_storage_account" "this" {
+  name = var.name
+}
+`,
+		"variables.tf": `variable "name" {
+  description = "storage account name"
+  type        = string
+}
+
+variable "tags" {
+  description = "tags to be added to the resources"
+  type        = map(string)
+  default     = {}
+}
+`,
+		"outputs.tf": `output "id" {
+  description = "storage account id"
+  value       = azurerm_storage_account.this.id
+}
+`,
+		"terraform.tf": `terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+  }
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithRelativeReadmePath(filepath.Join(dir, "README.md")),
+		markparsr.WithAdditionalSections("Notes"),
+		markparsr.WithProviderPrefixes("azurerm_"),
+		markparsr.WithOfflineURLs(),
+		markparsr.WithUpdateDocs(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	if readme, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(readme) != files["README.md"] {
+		t.Errorf("creating the validator modified the README")
+	}
+
+	for _, err := range validator.Validate() {
+		t.Errorf("Validation error after docs update: %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`- <a name="requirement_terraform"></a> [terraform](#requirement\_terraform) (>= 1.9.0)`,
+		`### <a name="input_name"></a> [name](#input\_name)`,
+		"Default: `{}`",
+		"Hand-written notes stay in place.",
+	} {
+		if !strings.Contains(string(readme), want) {
+			t.Errorf("updated README is missing %q", want)
+		}
+	}
+}
+
+func TestInjectDocs(t *testing.T) {
+	docs := "<!-- BEGIN_TF_DOCS -->\n## Outputs\n\nNo outputs.\n<!-- END_TF_DOCS -->"
+	example := "```markdown\n<!-- BEGIN_TF_DOCS -->\n<!-- END_TF_DOCS -->\n```\n"
+
+	tests := []struct {
+		name    string
+		readme  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "no markers",
+			readme: "# Module\n",
+			want:   "# Module\n\n" + docs + "\n",
+		},
+		{
+			name:   "markers in code",
+			readme: "# Module\n\nWrap the docs in `<!-- BEGIN_TF_DOCS -->` markers:\n\n" + example + "\n<!-- BEGIN_TF_DOCS -->\nstale\n<!-- END_TF_DOCS -->\n",
+			want:   "# Module\n\nWrap the docs in `<!-- BEGIN_TF_DOCS -->` markers:\n\n" + example + "\n" + docs + "\n",
+		},
+		{
+			name:    "end before begin",
+			readme:  "# Module\n\n<!-- END_TF_DOCS -->\n\n<!-- BEGIN_TF_DOCS -->\n",
+			wantErr: true,
+		},
+		{
+			name:    "unclosed",
+			readme:  "# Module\n\n<!-- BEGIN_TF_DOCS -->\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate begin",
+			readme:  "# Module\n\n<!-- BEGIN_TF_DOCS -->\n\n<!-- BEGIN_TF_DOCS -->\n\n<!-- END_TF_DOCS -->\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := markparsr.InjectDocs(tt.readme, docs)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got:\n%s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InjectDocs failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("InjectDocs:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestDocsBlockScope(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(`# Module
+
+<!-- BEGIN_TF_DOCS -->
+## Requirements
+
+No requirements.
+
+## Providers
+
+No providers.
+
+## Resources
+
+No resources.
+
+## Required Inputs
+
+No required inputs.
+
+## Optional Inputs
+
+No optional inputs.
+
+## Outputs
+
+### <a name="output_id"></a> [id](#output\_id)
+
+Description: id
+
+## Notes
+
+Written by hand.
+<!-- END_TF_DOCS -->
+
+## Outputs
+
+### <a name="output_legacy"></a> [legacy](#output\_legacy)
+
+Description: kept for reference
+`)},
+		"module/main.tf":    {Data: []byte("")},
+		"module/outputs.tf": {Data: []byte("output \"id\" {\n  value = \"id\"\n}\n")},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	var got []string
+	for _, err := range validator.Validate() {
+		var finding *markparsr.Finding
+		if errors.As(err, &finding) && finding.Line > 0 {
+			got = append(got, fmt.Sprintf("%s %d %s", finding.Rule, finding.Line, finding.Item))
+		}
+	}
+
+	want := []string{
+		"docs-block 30 Notes",
+		"docs-block 35 Outputs",
+		"duplicate 35 Outputs",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
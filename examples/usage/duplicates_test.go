@@ -0,0 +1,70 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestDuplicates(t *testing.T) {
+	readme := `# Module
+
+## Required Inputs
+
+### <a name="input_name"></a> [name](#input\_name)
+
+Description: name
+
+## Optional Inputs
+
+### <a name="input_name"></a> [name](#input\_name)
+
+Description: name
+
+### <a name="input_tags"></a> [tags](#input\_tags)
+
+Description: tags
+
+### <a name="input_tags"></a> [tags](#input\_tags)
+
+Description: tags
+
+## Outputs
+
+No outputs.
+
+## Outputs
+
+No outputs.
+`
+	fsys := fstest.MapFS{"module/README.md": {Data: []byte(readme)}}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	var got []string
+	for _, err := range validator.Validate() {
+		var finding *markparsr.Finding
+		if errors.As(err, &finding) && finding.Rule == markparsr.RuleDuplicate {
+			got = append(got, fmt.Sprintf("%d %s", finding.Line, finding.Message))
+		}
+	}
+
+	want := []string{
+		"11 input 'name' is documented in both Required Inputs and Optional Inputs",
+		"19 item 'tags' is documented more than once in section 'Optional Inputs'",
+		"27 section 'Outputs' is duplicated (first at line 23)",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("duplicate findings = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestFindingsAreSorted(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(`# Module
+
+See [usage](#usage).
+
+## Requirements
+
+## Providers
+
+## Resources
+
+## Required Inputs
+
+## Optional Inputs
+
+## Output
+
+### <a name="output_id"></a> [id](#output\_id)
+
+Description: stale output
+`)},
+		"module/main.tf": {Data: []byte("")},
+	}
+
+	want := []string{
+		"broken-anchor module/README.md:3",
+		"misspelled-section module/README.md:15",
+		"missing-in-terraform module/README.md:17",
+	}
+
+	for range 5 {
+		validator, err := markparsr.NewReadmeValidator(
+			markparsr.WithFS(fsys),
+			markparsr.WithRelativeReadmePath("module/README.md"),
+			markparsr.WithConcurrency(4),
+		)
+		if err != nil {
+			t.Fatalf("Failed to create validator: %v", err)
+		}
+
+		var got []string
+		for _, err := range validator.Validate() {
+			var finding *markparsr.Finding
+			if !errors.As(err, &finding) {
+				t.Fatalf("unexpected plain error: %v", err)
+			}
+			if finding.Line > 0 {
+				got = append(got, fmt.Sprintf("%s %s:%d", finding.Rule, finding.File, finding.Line))
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("findings = %v, want %v", got, want)
+		}
+	}
+}
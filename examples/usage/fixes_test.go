@@ -0,0 +1,252 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestFixFindings(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"README.md": `# Storage
+
+<!-- BEGIN_TF_DOCS -->
+## Requirements
+
+No requirements.
+
+## Providers
+
+No providers.
+
+## Resources
+
+No resources.
+
+## Required Inputs
+
+The following input variables are required:
+
+### <a name="input_name"></a> [name](#input\_name)
+
+Description: storage account name
+
+Type: ` + "`string`" + `
+
+## Optional Inputs
+
+The following input variables are optional (have default values):
+
+### <a name="input_location"></a> [location](#input\_location)
+
+Description: location
+
+Type: ` + "`string`" + `
+
+Default: ` + "`null`" + `
+
+## Output
+
+The following outputs are exported:
+
+### <a name="output_id"></a> [id](#output\_id)
+
+Description: storage account id
+
+### <a name="output_old"></a> [old](#output\_old)
+
+Description: removed output
+<!-- END_TF_DOCS -->
+`,
+		"main.tf": "",
+		"variables.tf": `variable "name" {
+  description = "storage account name"
+  type        = string
+}
+
+variable "location" {
+  description = "location"
+  type        = string
+  default     = null
+}
+
+variable "tags" {
+  description = "tags to be added to the resources"
+  type        = map(string)
+  default     = {}
+}
+`,
+		"outputs.tf": `output "id" {
+  description = "storage account id"
+  value       = "id"
+}
+`,
+		"terraform.tf": "terraform {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithRelativeReadmePath(filepath.Join(dir, "README.md")),
+		markparsr.WithFix(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	for _, err := range validator.Validate() {
+		t.Errorf("Finding left after fixing: %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(readme)
+
+	if !strings.Contains(content, "## Outputs\n") {
+		t.Errorf("misspelled section was not renamed:\n%s", content)
+	}
+	if strings.Contains(content, "output_old") {
+		t.Errorf("stale output was not removed:\n%s", content)
+	}
+	if !strings.Contains(content, "Description: storage account id\n<!-- END_TF_DOCS -->") {
+		t.Errorf("removing the last output left stray content:\n%s", content)
+	}
+	location := strings.Index(content, `name="input_location"`)
+	tags := strings.Index(content, `name="input_tags"`)
+	if location == -1 || tags < location {
+		t.Errorf("missing variable was not inserted after location:\n%s", content)
+	}
+
+	for _, err := range validator.Validate() {
+		t.Errorf("Finding after reload: %v", err)
+	}
+}
+
+func TestFixMisspelledSectionDryRun(t *testing.T) {
+	readme := "# Module\n\n## Requirments ##\n\nNo requirements.\n\n## Notes\n"
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(readme)},
+		"module/main.tf":   {Data: []byte("")},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+		markparsr.WithFix(),
+		markparsr.WithDryRun(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	findings := validator.Validate()
+	fixed, _ := markparsr.ApplyFixes(readme, findings)
+	if !strings.Contains(fixed, "## Requirements ##\n") {
+		t.Fatalf("misspelled heading was not renamed in place:\n%s", fixed)
+	}
+	if string(fsys["module/README.md"].Data) != readme {
+		t.Errorf("dry run modified the README")
+	}
+
+	want := `--- a/module/README.md
++++ b/module/README.md
+@@ -1,6 +1,6 @@
+ # Module
+ 
+-## Requirments ##
++## Requirements ##
+ 
+ No requirements.
+ 
+`
+	if diff := markparsr.UnifiedDiff("module/README.md", readme, fixed); diff != want {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+}
+
+func TestFixHeadingPositions(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+	}{
+		{name: "setext heading", before: "Usage\n-----\n\nSee the examples.\n"},
+		{name: "commented heading", before: "<!--\n## Outptus\n-->\n"},
+		{name: "html block", before: "<details>\n<summary>Notes</summary>\n\n## Provders\n\n</details>\n"},
+		{name: "fenced heading", before: "```markdown\n## Requirments\n```\n"},
+		{name: "blockquote heading", before: "> ### Notes\n>\n> Mind the Requirments below.\n"},
+		{name: "list item heading", before: "- ### Notes\n- Requirments\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readme := "# Module\n\n" + tt.before + "\n## Resources\n\nNo resources.\n\n## Requirments\n\nNo requirements.\n\n## Providers\n\nNo providers.\n"
+			fsys := fstest.MapFS{
+				"module/README.md": {Data: []byte(readme)},
+				"module/main.tf":   {Data: []byte("")},
+			}
+
+			validator, err := markparsr.NewReadmeValidator(
+				markparsr.WithFS(fsys),
+				markparsr.WithRelativeReadmePath("module/README.md"),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+
+			heading := strings.LastIndex(readme, "## Requirments")
+			findings := validator.Validate()
+			for _, err := range findings {
+				var finding *markparsr.Finding
+				if errors.As(err, &finding) && finding.Rule == markparsr.RuleMisspelledSection {
+					if want := strings.Count(readme[:heading], "\n") + 1; finding.Line != want {
+						t.Errorf("line = %d, want %d", finding.Line, want)
+					}
+				}
+			}
+
+			fixed, _ := markparsr.ApplyFixes(readme, findings)
+			if want := readme[:heading] + "## Requirements" + readme[heading+len("## Requirments"):]; fixed != want {
+				t.Errorf("fixed README:\n%s\nwant:\n%s", fixed, want)
+			}
+		})
+	}
+}
+
+func TestFixNestedHeading(t *testing.T) {
+	readme := "# Module\n\n> **Note**\n>\n> ## Requirments\n>\n> No requirements.\n\n## Providers\n\nNo providers.\n"
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(readme)},
+		"module/main.tf":   {Data: []byte("")},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	findings := validator.Validate()
+	for _, err := range findings {
+		var finding *markparsr.Finding
+		if errors.As(err, &finding) && finding.Rule == markparsr.RuleMisspelledSection && finding.Line != 5 {
+			t.Errorf("line = %d, want 5", finding.Line)
+		}
+	}
+
+	fixed, _ := markparsr.ApplyFixes(readme, findings)
+	if want := strings.Replace(readme, "> ## Requirments", "> ## Requirements", 1); fixed != want {
+		t.Errorf("fixed README:\n%s\nwant:\n%s", fixed, want)
+	}
+}
@@ -0,0 +1,166 @@
+package test
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestGitFS(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(message string, files map[string]string) string {
+		t.Helper()
+		for name, data := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := worktree.AddGlob("."); err != nil {
+			t.Fatal(err)
+		}
+		hash, err := worktree.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash.String()
+	}
+
+	base := commit("base", map[string]string{
+		"module/README.md":    "# Module\n",
+		"module/main.tf":      "resource \"azurerm_resource_group\" \"this\" {}\n",
+		"module/variables.tf": "variable \"name\" {}\n",
+	})
+	head := commit("head", map[string]string{
+		"module/README.md":           "# Module\n\n## Outputs\n",
+		"module/outputs.tf":          "output \"id\" {\n  value = \"id\"\n}\n",
+		"module/examples/default.tf": "module \"storage\" {\n  source = \"../\"\n}\n",
+	})
+
+	index, err := repo.Storer.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitlink := index.Add("module/zz-submodule")
+	gitlink.Mode = filemode.Submodule
+	gitlink.Hash = plumbing.NewHash(head)
+	if err := repo.Storer.SetIndex(index); err != nil {
+		t.Fatal(err)
+	}
+	submodule, err := worktree.Commit("submodule", &git.CommitOptions{
+		Author:            &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		revision string
+		files    []string
+		missing  []string
+		readme   string
+	}{
+		{
+			name:     "base",
+			revision: base,
+			files:    []string{"module/README.md", "module/main.tf", "module/variables.tf"},
+			missing:  []string{"module/outputs.tf", "module/examples"},
+			readme:   "# Module\n",
+		},
+		{
+			name:     "head",
+			revision: "HEAD",
+			files:    []string{"module/README.md", "module/main.tf", "module/variables.tf", "module/outputs.tf", "module/examples/default.tf"},
+			readme:   "# Module\n\n## Outputs\n",
+		},
+		{
+			name:     "trailing submodule",
+			revision: submodule.String(),
+			files:    []string{"module/README.md", "module/outputs.tf", "module/examples/default.tf"},
+			missing:  []string{"module/zz-submodule"},
+			readme:   "# Module\n\n## Outputs\n",
+		},
+		{
+			name:     "head by hash",
+			revision: head,
+			files:    []string{"module/outputs.tf", "module/examples/default.tf"},
+			readme:   "# Module\n\n## Outputs\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys, err := markparsr.NewGitFS(dir, tt.revision)
+			if err != nil {
+				t.Fatalf("NewGitFS(%q) failed: %v", tt.revision, err)
+			}
+			if err := fstest.TestFS(fsys, tt.files...); err != nil {
+				t.Fatal(err)
+			}
+
+			dir, err := fsys.Open("module")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dir.Close()
+			var paged int
+			for {
+				entries, err := dir.(fs.ReadDirFile).ReadDir(1)
+				if err == io.EOF {
+					break
+				}
+				if err != nil || len(entries) == 0 {
+					t.Fatalf("ReadDir(1) = %v, %v after %d entries, want an entry or io.EOF", entries, err, paged)
+				}
+				paged += len(entries)
+			}
+			if all, _ := fs.ReadDir(fsys, "module"); paged != len(all) {
+				t.Errorf("ReadDir(1) returned %d entries, ReadDir(-1) returned %d", paged, len(all))
+			}
+
+			for _, name := range tt.missing {
+				if _, err := fs.Stat(fsys, name); !os.IsNotExist(err) {
+					t.Errorf("expected %s to be missing at %s, got %v", name, tt.revision, err)
+				}
+			}
+
+			readme, err := fs.ReadFile(fsys, "module/README.md")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(readme) != tt.readme {
+				t.Errorf("README at %s = %q, want %q", tt.revision, readme, tt.readme)
+			}
+		})
+	}
+
+	if _, err := markparsr.NewGitFS(dir, "does-not-exist"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+}
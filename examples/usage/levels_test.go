@@ -0,0 +1,122 @@
+package test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+const nestedReadme = `# Module
+
+## Usage
+
+See the examples.
+
+## Reference
+
+<!-- BEGIN_TF_DOCS -->
+### Requirements
+
+No requirements.
+
+### Providers
+
+No providers.
+
+### Resources
+
+No resources.
+
+### Required Inputs
+
+The following input variables are required:
+
+#### <a name="input_name"></a> [name](#input\_name)
+
+Description: name
+
+### Optional Inputs
+
+The following input variables are optional (have default values):
+
+#### <a name="input_tags"></a> [tags](#input\_tags)
+
+Description: tags
+
+### Outputs
+
+The following outputs are exported:
+
+#### <a name="output_id"></a> [id](#output\_id)
+
+Description: id
+<!-- END_TF_DOCS -->
+
+## Goals
+
+Keep it simple.
+`
+
+func TestHeadingLevels(t *testing.T) {
+	module := fstest.MapFS{
+		"module/main.tf":      {Data: []byte("")},
+		"module/terraform.tf": {Data: []byte("terraform {}\n")},
+		"module/variables.tf": {Data: []byte("variable \"name\" {}\n\nvariable \"tags\" {\n  default = {}\n}\n")},
+		"module/outputs.tf":   {Data: []byte("output \"id\" {\n  value = var.name\n}\n")},
+	}
+
+	tests := []struct {
+		name   string
+		readme string
+		opts   []markparsr.Option
+		want   []string
+	}{
+		{
+			name:   "derived from the terraform-docs block",
+			readme: nestedReadme,
+		},
+		{
+			name:   "configured",
+			readme: strings.NewReplacer("<!-- BEGIN_TF_DOCS -->\n", "", "<!-- END_TF_DOCS -->\n", "").Replace(nestedReadme),
+			opts:   []markparsr.Option{markparsr.WithHeadingLevels(3, 4)},
+		},
+		{
+			name:   "hand-written sections stay at H2",
+			readme: strings.Replace(nestedReadme, "## Usage\n\nSee the examples.\n\n", "## Goals\n\nKeep it small.\n\n## Usage\n\nSee the examples.\n\n", 1),
+			opts:   []markparsr.Option{markparsr.WithSectionOrder("Usage", markparsr.SectionDocsBlock, "Goals")},
+			want: []string{
+				"section 'Goals' is out of order: expected at position 3, after the terraform-docs block",
+				"section 'Goals' is duplicated (first at line 3)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"module/README.md": {Data: []byte(tt.readme)}}
+			for name, file := range module {
+				fsys[name] = file
+			}
+
+			validator, err := markparsr.NewReadmeValidator(append([]markparsr.Option{
+				markparsr.WithFS(fsys),
+				markparsr.WithRelativeReadmePath("module/README.md"),
+				markparsr.WithAdditionalSections("Goals", "Usage"),
+			}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+
+			var got []string
+			for _, err := range validator.Validate() {
+				got = append(got, err.Error())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestRelativeLinks(t *testing.T) {
+	readme := `# Storage
+
+See the [guide](/docs/guide.md#setup), the [missing guide](/docs/missing.md) and the [root anchor](/docs/guide.md#teardown).
+
+For more information, please see our [goals and non-goals](./GOALS.md#non-goals) and [testing guidelines](TESTING.md#testing).
+
+Broken fragments: [goals](./GOALS.md#roadmap) and [testing](/modules/storage/TESTING.md#coverage).
+
+![diagram](../../docs/diagram.png)
+`
+	files := map[string]string{
+		"docs/guide.md":              "# Guide\n\n## Setup\n",
+		"docs/diagram.png":           "",
+		"modules/storage/README.md":  readme,
+		"modules/storage/GOALS.md":   "## Goals\n\n## Non-Goals\n",
+		"modules/storage/TESTING.md": "## Testing\n",
+	}
+	want := []string{
+		"relative link target not found: /docs/missing.md",
+		"anchor not found in guide.md: #teardown",
+		"anchor not found in GOALS.md: #roadmap",
+		"anchor not found in TESTING.md: #coverage",
+	}
+
+	tests := []struct {
+		name string
+		root string
+		git  bool
+	}{
+		{name: "filesystem root", root: ""},
+		{name: "git repository root", root: "repo/", git: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for name, data := range files {
+				fsys[tt.root+name] = &fstest.MapFile{Data: []byte(data)}
+			}
+			if tt.git {
+				fsys[tt.root+".git/HEAD"] = &fstest.MapFile{Data: []byte("ref: refs/heads/main\n")}
+				fsys["docs/guide.md"] = &fstest.MapFile{Data: []byte("# Outside the repository\n")}
+			}
+
+			content := markparsr.NewMarkdownContent(readme, markparsr.FormatDocument, nil)
+			validator := markparsr.NewRelativeLinkValidator(fsys, tt.root+"modules/storage/README.md", tt.root+"modules/storage", content)
+
+			var got []string
+			for _, err := range validator.Validate() {
+				got = append(got, err.Error())
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("findings = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -1,17 +1,117 @@
 package test
 
 import (
-	"github.com/cloudnationhq/az-cn-go-markparsr"
+	"os"
 	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
 )
 
 func TestReadmeValidation(t *testing.T) {
 
-	validator, err := markparsr.NewReadmeValidator(
+	opts := []markparsr.Option{
 		markparsr.WithRelativeReadmePath("../module/README.md"),
 		markparsr.WithAdditionalSections("Goals", "Testing", "Notes"),
 		markparsr.WithAdditionalFiles("GOALS.md", "TESTING.md"),
 		markparsr.WithProviderPrefixes("azurerm_", "random_", "tls_"),
+	}
+	if os.Getenv("FIX") == "true" {
+		opts = append(opts, markparsr.WithFix())
+	}
+	if os.Getenv("DRY_RUN") == "true" {
+		opts = append(opts, markparsr.WithDryRun())
+	}
+
+	validator, err := markparsr.NewReadmeValidator(opts...)
+
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	if os.Getenv("UPDATE_DOCS") == "true" {
+		if err := validator.UpdateDocs(); err != nil {
+			t.Fatalf("Failed to update docs: %v", err)
+		}
+	}
+
+	errors := validator.Validate()
+	if len(errors) > 0 {
+		for _, err := range errors {
+			t.Errorf("Validation error: %v", err)
+		}
+	}
+}
+
+func TestReadmeValidationFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"modules/storage/README.md": {Data: []byte(`# Storage
+
+<!-- BEGIN_TF_DOCS -->
+## Requirements
+
+- <a name="requirement_azurerm"></a> [azurerm](#requirement\_azurerm) (~> 4.0)
+
+## Providers
+
+- <a name="provider_azurerm"></a> [azurerm](#provider\_azurerm) (~> 4.0)
+
+## Resources
+
+- [azurerm_storage_account.this](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/storage_account) (resource)
+
+## Required Inputs
+
+### <a name="input_name"></a> [name](#input\_name)
+
+Description: storage account name
+
+## Optional Inputs
+
+### <a name="input_tags"></a> [tags](#input\_tags)
+
+Description: tags to be added to the resources
+
+## Outputs
+
+### <a name="output_id"></a> [id](#output\_id)
+
+Description: storage account id
+<!-- END_TF_DOCS -->
+`)},
+		"modules/storage/main.tf": {Data: []byte(`resource "azurerm_storage_account" "this" {
+  name = var.name
+  tags = var.tags
+}
+`)},
+		"modules/storage/variables.tf": {Data: []byte(`variable "name" {
+  type = string
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+`)},
+		"modules/storage/outputs.tf": {Data: []byte(`output "id" {
+  value = azurerm_storage_account.this.id
+}
+`)},
+		"modules/storage/terraform.tf": {Data: []byte(`terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+  }
+}
+`)},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("modules/storage/README.md"),
+		markparsr.WithProviderPrefixes("azurerm_"),
 	)
 
 	if err != nil {
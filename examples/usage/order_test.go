@@ -0,0 +1,104 @@
+package test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestSectionOrder(t *testing.T) {
+	readme := `# Module
+
+Deploys things.
+
+## Goals
+
+Keep it simple.
+
+## Usage
+
+See examples.
+
+<!-- BEGIN_TF_DOCS -->
+## Requirements
+
+No requirements.
+<!-- END_TF_DOCS -->
+
+## Notes
+
+Nothing yet.
+
+## Testing
+
+Run make test.
+`
+	fsys := fstest.MapFS{"module/README.md": {Data: []byte(readme)}}
+
+	tests := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{
+			name: "no order configured",
+		},
+		{
+			name:  "style guide order",
+			order: []string{"Usage", markparsr.SectionDocsBlock, "Goals", "Testing", "Notes"},
+			want: []string{
+				"section 'Goals' is out of order: expected at position 3, after the terraform-docs block",
+				"section 'Testing' is out of order: expected at position 4, after section 'Goals'",
+			},
+		},
+		{
+			name:  "title and description",
+			order: []string{markparsr.SectionTitle, markparsr.SectionDescription, "Usage", "Goals"},
+			want: []string{
+				"section 'Usage' is out of order: expected at position 3, after the description",
+			},
+		},
+		{
+			name:  "description after usage",
+			order: []string{"Usage", markparsr.SectionDescription, markparsr.SectionTitle},
+			want: []string{
+				"the description is out of order: expected at position 2, after section 'Usage'",
+				"section 'Usage' is out of order: expected at position 1, before the description",
+			},
+		},
+		{
+			name:  "generated sections listed individually",
+			order: []string{"Requirements", "Usage"},
+			want: []string{
+				"section 'Requirements' is out of order: expected at position 1, before section 'Usage'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := markparsr.NewReadmeValidator(
+				markparsr.WithFS(fsys),
+				markparsr.WithRelativeReadmePath("module/README.md"),
+				markparsr.WithSectionOrder(tt.order...),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+
+			var got []string
+			for _, err := range validator.Validate() {
+				var finding *markparsr.Finding
+				if errors.As(err, &finding) && finding.Rule == markparsr.RuleSectionOrder {
+					got = append(got, finding.Message)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("order findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
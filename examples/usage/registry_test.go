@@ -0,0 +1,85 @@
+package test
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestRegistryLinks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/main.tf": {Data: []byte(`resource "azurerm_storage_account" "this" {}
+resource "azurerm_resource_group" "this" {}
+resource "azapi_resource" "this" {}
+data "azurerm_client_config" "current" {}
+`)},
+		"module/terraform.tf": {Data: []byte(`terraform {
+  required_providers {
+    azurerm = {
+      source = "hashicorp/azurerm"
+    }
+    azapi = {
+      source = "azure/azapi"
+    }
+  }
+}
+`)},
+	}
+	terraform, err := markparsr.NewTerraformContentFS(fsys, "module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const registry = "https://registry.terraform.io/providers/"
+	links := `- [azurerm_storage_account.this](` + registry + `hashicorp/azurerm/latest/docs/resources/storage_account) (resource)
+- [azurerm_resource_group.this](` + registry + `hashicorp/azurerm/latest/docs/resources/storage_account) (resource)
+- [azapi_resource.this](` + registry + `hashicorp/azapi/latest/docs/resources/resource) (resource)
+- [azurerm_client_config.current](` + registry + `hashicorp/azurerm/latest/docs/resources/client_config) (data source)
+`
+	prose := "Naming follows [azurerm_storage_account](https://learn.microsoft.com/azure/storage/common/storage-account-overview) rules.\n"
+
+	tests := []struct {
+		name   string
+		readme string
+		want   []string
+	}{
+		{
+			name:   "resources section",
+			readme: "# Module\n\n" + prose + "\n## Resources\n\n" + links,
+			want: []string{
+				"resource link for azurerm_resource_group.this points to storage_account (expected resource_group): " + registry + "hashicorp/azurerm/latest/docs/resources/storage_account",
+				"resource link for azapi_resource.this points to namespace hashicorp (expected azure): " + registry + "hashicorp/azapi/latest/docs/resources/resource",
+				"resource link for azurerm_client_config.current points to /resources/ (expected /data-sources/): " + registry + "hashicorp/azurerm/latest/docs/resources/client_config",
+			},
+		},
+		{
+			name:   "no resources section",
+			readme: "# Module\n\n" + prose + "\n## Links\n\n" + links,
+			want: []string{
+				"resource link for azurerm_resource_group.this points to storage_account (expected resource_group): " + registry + "hashicorp/azurerm/latest/docs/resources/storage_account",
+				"resource link for azapi_resource.this points to namespace hashicorp (expected azure): " + registry + "hashicorp/azapi/latest/docs/resources/resource",
+				"resource link for azurerm_client_config.current points to /resources/ (expected /data-sources/): " + registry + "hashicorp/azurerm/latest/docs/resources/client_config",
+			},
+		},
+		{
+			name:   "prose only",
+			readme: "# Module\n\n" + prose,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := markparsr.NewMarkdownContent(tt.readme, markparsr.FormatDocument, []string{"azurerm_", "azapi_"})
+
+			var got []string
+			for _, err := range markparsr.NewRegistryLinkValidator(content, terraform).Validate() {
+				got = append(got, err.Error())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestSectionSchema(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(`# Module
+
+## Requirements
+
+No requirements.
+
+## Providers
+
+No providers.
+
+## Resources
+
+No resources.
+
+## Inputs
+
+### <a name="input_tags"></a> [tags](#input\_tags)
+
+Description: tags
+`)},
+		"module/main.tf":      {Data: []byte("")},
+		"module/variables.tf": {Data: []byte("variable \"tags\" {\n  default = {}\n}\n")},
+	}
+
+	tests := []struct {
+		name   string
+		schema []markparsr.SectionSchema
+		want   []string
+	}{
+		{
+			name: "default schema skips sections the module does not need",
+			want: []string{"required section missing: 'Optional Inputs'"},
+		},
+		{
+			name: "custom schema",
+			schema: []markparsr.SectionSchema{
+				{Name: "Requirements", Rule: markparsr.SectionRequired},
+				{Name: "Optional Inputs", Rule: markparsr.SectionRequired, Aliases: []string{"Inputs"}},
+				{Name: "Usage", Rule: markparsr.SectionOptional},
+				{Name: "Outputs", Rule: markparsr.SectionConditional, When: markparsr.WhenDeclared("output")},
+				{Name: "Examples", Rule: markparsr.SectionConditional, When: func(context.Context, *markparsr.TerraformContent) (bool, error) {
+					return true, nil
+				}},
+			},
+			want: []string{"required section missing: 'Examples'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := markparsr.NewReadmeValidator(
+				markparsr.WithFS(fsys),
+				markparsr.WithRelativeReadmePath("module/README.md"),
+				markparsr.WithSectionSchema(tt.schema...),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+
+			var got []string
+			for _, err := range validator.Validate() {
+				var finding *markparsr.Finding
+				if errors.As(err, &finding) && finding.Rule == markparsr.RuleMissingSection {
+					got = append(got, finding.Message)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("section findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectionAliases(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(`# Module
+
+## Requirements
+
+No requirements.
+
+## Providers
+
+No providers.
+
+## Inputs
+
+### <a name="input_tags"></a> [tags](#input\_tags)
+
+Description: tags
+
+## Optional Inputs
+
+No optional inputs.
+`)},
+		"module/main.tf":      {Data: []byte("")},
+		"module/variables.tf": {Data: []byte("variable \"tags\" {\n  default = {}\n}\n")},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+		markparsr.WithSectionSchema(
+			markparsr.SectionSchema{Name: "Requirements", Rule: markparsr.SectionRequired},
+			markparsr.SectionSchema{Name: "Optional Inputs", Rule: markparsr.SectionRequired, Aliases: []string{"Inputs"}},
+		),
+		markparsr.WithSectionOrder("Requirements", "Optional Inputs", "Providers"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	var got []string
+	for _, err := range validator.Validate() {
+		var finding *markparsr.Finding
+		if errors.As(err, &finding) && finding.Rule != markparsr.RuleRequiredFile {
+			got = append(got, finding.Message)
+		}
+	}
+	want := []string{
+		"section 'Providers' is out of order: expected at position 4, after section 'Optional Inputs'",
+		"section 'Optional Inputs' is duplicated (first at line 11)",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("findings = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+const tableReadme = `# Storage
+
+<!-- BEGIN_TF_DOCS -->
+## Requirements
+
+| Name | Version |
+|------|---------|
+| <a name="requirement_terraform"></a> [terraform](#requirement\_terraform) | >= 1.9.0 |
+| <a name="requirement_azurerm"></a> [azurerm](#requirement\_azurerm) | ~> 4.0 |
+
+## Providers
+
+| Name | Version |
+|------|---------|
+| <a name="provider_azurerm"></a> [azurerm](#provider\_azurerm) | ~> 4.0 |
+
+## Resources
+
+| Name | Type |
+|------|------|
+| [azurerm_storage_account.this](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/storage_account) | resource |
+| [azurerm_client_config.current](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/data-sources/client_config) | data source |
+
+## Inputs
+
+| Name | Description | Type | Default | Required |
+|------|-------------|------|---------|:--------:|
+| <a name="input_config"></a> [config](#input\_config) | storage account configuration | <pre>object({<br/>  name = string<br/>})</pre> | n/a | yes |
+| <a name="input_resource_group_name"></a> [resource\_group\_name](#input\_resource\_group\_name) | default resource group | ` + "`string`" + ` | ` + "`null`" + ` | no |
+
+## Outputs
+
+| Name | Description |
+|------|-------------|
+| <a name="output_id"></a> [id](#output\_id) | storage account id |
+<!-- END_TF_DOCS -->
+`
+
+func TestReadmeValidationTableFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(tableReadme)},
+		"module/main.tf": {Data: []byte(`data "azurerm_client_config" "current" {}
+
+resource "azurerm_storage_account" "this" {
+  name = var.config.name
+}
+`)},
+		"module/variables.tf": {Data: []byte(`variable "config" {
+  type = object({
+    name = string
+  })
+}
+
+variable "resource_group_name" {
+  type    = string
+  default = null
+}
+`)},
+		"module/outputs.tf": {Data: []byte("output \"id\" {\n  value = azurerm_storage_account.this.id\n}\n")},
+		"module/terraform.tf": {Data: []byte(`terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+  }
+}
+`)},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+		markparsr.WithFormat(markparsr.FormatTable),
+		markparsr.WithProviderPrefixes("azurerm_"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	if format := validator.GetFormat(); format != markparsr.FormatTable {
+		t.Errorf("format = %s, want %s", format, markparsr.FormatTable)
+	}
+	for _, err := range validator.Validate() {
+		t.Errorf("Validation error: %v", err)
+	}
+}
+
+func TestExtractTableItems(t *testing.T) {
+	content := markparsr.NewMarkdownContent(tableReadme, markparsr.FormatTable, nil)
+
+	required := content.ExtractTableItems("Required Inputs")
+	if len(required) != 1 || required[0].Name != "config" || required[0].Type != "object({\n  name = string\n})" {
+		t.Errorf("required inputs = %+v", required)
+	}
+
+	optional := content.ExtractTableItems("Optional Inputs")
+	if len(optional) != 1 || optional[0].Name != "resource_group_name" || optional[0].Type != "string" || optional[0].Default != "null" {
+		t.Errorf("optional inputs = %+v", optional)
+	}
+
+	providers := content.ExtractTableItems("Requirements")
+	if len(providers) != 2 || providers[1].Name != "azurerm" || providers[1].Version != "~> 4.0" {
+		t.Errorf("requirements = %+v", providers)
+	}
+}
+
+func TestFormatDetection(t *testing.T) {
+	mixed := tableReadme + `
+## Outputs
+
+### <a name="output_name"></a> [name](#output\_name)
+
+Description: storage account name
+`
+
+	tests := []struct {
+		name   string
+		readme string
+		want   markparsr.MarkdownFormat
+	}{
+		{name: "table", readme: tableReadme, want: markparsr.FormatTable},
+		{name: "document outside block ignored", readme: mixed, want: markparsr.FormatTable},
+		{name: "document", readme: "## Outputs\n\n### <a name=\"output_id\"></a> [id](#output\\_id)\n\nDescription: id\n", want: markparsr.FormatDocument},
+		{name: "empty", readme: "# Module\n", want: markparsr.FormatDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := markparsr.NewReadmeValidator(
+				markparsr.WithFS(fstest.MapFS{"module/README.md": {Data: []byte(tt.readme)}}),
+				markparsr.WithRelativeReadmePath("module/README.md"),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+			if format := validator.GetFormat(); format != tt.want {
+				t.Errorf("format = %s, want %s", format, tt.want)
+			}
+		})
+	}
+}
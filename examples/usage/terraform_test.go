@@ -0,0 +1,125 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestExtractItemsPaths(t *testing.T) {
+	want := []string{"location", "resource_group_name", "tags", "naming", "config"}
+
+	absolute, err := filepath.Abs("../module/variables.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := markparsr.NewTerraformContent("../module")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{"module/variables.tf": {Data: []byte(`variable "location" {}
+variable "resource_group_name" {}
+variable "tags" {}
+variable "naming" {}
+variable "config" {}
+`)}}
+	inMemory, err := markparsr.NewTerraformContentFS(fsys, "module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		terraform *markparsr.TerraformContent
+		path      string
+		missing   bool
+	}{
+		{name: "absolute", terraform: local, path: absolute},
+		{name: "relative to the module", terraform: local, path: "variables.tf"},
+		{name: "relative to the working directory", terraform: local, path: "terraform_test.go", missing: true},
+		{name: "relative to the module in fs", terraform: inMemory, path: "variables.tf"},
+		{name: "relative to the fs root", terraform: inMemory, path: "module/variables.tf", missing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := tt.terraform.ExtractItems(tt.path, "variable")
+			if tt.missing {
+				if !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("ExtractItems(%q) = %v, %v, want a not-found error", tt.path, items, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractItems(%q) failed: %v", tt.path, err)
+			}
+			if !slices.Equal(items, want) {
+				t.Errorf("ExtractItems(%q) = %v, want %v", tt.path, items, want)
+			}
+		})
+	}
+}
+
+func TestOverrideFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/main.tf": {Data: []byte("")},
+		"module/variables.tf": {Data: []byte(`variable "name" {
+  description = "name"
+  type        = string
+}
+`)},
+		"module/variables_override.tf": {Data: []byte(`variable "name" {
+  description = "storage account names"
+  type        = list(string)
+  default     = ["demo"]
+}
+`)},
+		"module/README.md": {Data: []byte("# Module\n\n## Usage\n\n```hcl\nmodule \"storage\" {\n  source = \"./module\"\n}\n```\n")},
+	}
+
+	terraform, err := markparsr.NewTerraformContentFS(fsys, "module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := terraform.GenerateDocs()
+	if err != nil {
+		t.Fatalf("Failed to generate docs: %v", err)
+	}
+	_, optional, _ := strings.Cut(docs, "## Optional Inputs")
+	for _, want := range []string{"[name](#input\\_name)", "Description: storage account names", "Type: `list(string)`", `Default:`, `"demo"`} {
+		if !strings.Contains(optional, want) {
+			t.Errorf("expected overridden variable in Optional Inputs with %q:\n%s", want, docs)
+		}
+	}
+
+	required, err := markparsr.WhenVariables(true)(context.Background(), terraform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if required {
+		t.Error("expected no required variables once the override adds a default")
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	for _, err := range validator.Validate() {
+		var finding *markparsr.Finding
+		if errors.As(err, &finding) && finding.Rule == markparsr.RuleUsage {
+			t.Errorf("unexpected usage finding: %v", err)
+		}
+	}
+}
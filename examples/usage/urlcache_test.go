@@ -0,0 +1,146 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func writeURLCache(t *testing.T, path string, entries map[string]markparsr.URLCacheEntry) {
+	t.Helper()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readURLCache(t *testing.T, path string) map[string]markparsr.URLCacheEntry {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries map[string]markparsr.URLCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestURLCacheTTL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.json")
+	writeURLCache(t, path, map[string]markparsr.URLCacheEntry{
+		"https://example.com/fresh": {Status: 200, CheckedAt: time.Now().Add(-time.Minute)},
+		"https://example.com/stale": {Status: 200, CheckedAt: time.Now().Add(-2 * time.Hour)},
+	})
+
+	long, err := markparsr.OpenURLCache(path, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	short, err := markparsr.OpenURLCache(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := short.Lookup("https://example.com/fresh"); !ok {
+		t.Error("expected fresh entry to be a cache hit")
+	}
+	if _, ok := short.Lookup("https://example.com/stale"); ok {
+		t.Error("expected entry older than the TTL to be a cache miss")
+	}
+	if _, ok := long.Lookup("https://example.com/stale"); !ok {
+		t.Error("expected reopening the cache with a shorter TTL to leave the first TTL alone")
+	}
+	if _, ok := long.Lookup("https://example.com/missing"); ok {
+		t.Error("expected unknown URL to be a cache miss")
+	}
+
+	short.Store("https://example.com/new", 200, "")
+	if _, ok := long.Lookup("https://example.com/new"); !ok {
+		t.Error("expected entries to be shared between openers of the same path")
+	}
+}
+
+func TestURLCacheMergeOnSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.json")
+	cache, err := markparsr.OpenURLCache(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Store("https://example.com/ours", 200, "")
+	cache.Store("https://example.com/both", 404, "")
+
+	later := time.Now().Add(time.Minute).UTC()
+	writeURLCache(t, path, map[string]markparsr.URLCacheEntry{
+		"https://example.com/theirs": {Status: 200, CheckedAt: time.Now().UTC()},
+		"https://example.com/both":   {Status: 200, CheckedAt: later},
+	})
+
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	entries := readURLCache(t, path)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 merged entries, got %v", entries)
+	}
+	if entries["https://example.com/ours"].Status != 200 || entries["https://example.com/theirs"].Status != 200 {
+		t.Errorf("expected entries from both writers, got %v", entries)
+	}
+	if entry := entries["https://example.com/both"]; entry.Status != 200 || !entry.CheckedAt.Equal(later) {
+		t.Errorf("expected the newer on-disk entry to win, got %+v", entry)
+	}
+}
+
+func TestURLCacheAcrossModules(t *testing.T) {
+	for _, env := range []string{"OFFLINE", "URL_CACHE_PATH", "URL_CACHE_TTL"} {
+		t.Setenv(env, "")
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "urls.json")
+	readme := fmt.Sprintf("# Module\n\nSee the [docs](%s/docs).\n", server.URL)
+	fsys := fstest.MapFS{
+		"first/README.md":  {Data: []byte(readme)},
+		"second/README.md": {Data: []byte(readme)},
+	}
+
+	for _, module := range []string{"first", "second"} {
+		validator, err := markparsr.NewReadmeValidator(
+			markparsr.WithFS(fsys),
+			markparsr.WithRelativeReadmePath(module+"/README.md"),
+			markparsr.WithHTTPClient(server.Client()),
+			markparsr.WithURLCache(path, time.Hour),
+		)
+		if err != nil {
+			t.Fatalf("Failed to create validator for %s: %v", module, err)
+		}
+		validator.Validate()
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected the second module to reuse the cached result, got %d requests", got)
+	}
+	if _, ok := readURLCache(t, path)[server.URL+"/docs"]; !ok {
+		t.Errorf("expected %s/docs to be persisted", server.URL)
+	}
+}
@@ -0,0 +1,424 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestURLValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	content := markparsr.NewMarkdownContent(fmt.Sprintf(`# Module
+
+See the [docs](%[1]s/docs) and the [changelog](%[1]s/missing).
+
+Also see [upstream](https://example.com/upstream).
+`, server.URL), markparsr.FormatDocument, nil)
+
+	validator := markparsr.NewURLValidator(content, markparsr.URLConfig{
+		Client:  server.Client(),
+		Offline: true,
+	})
+
+	errors := validator.Validate()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 validation error, got %d: %v", len(errors), errors)
+	}
+
+	skipped := validator.SkippedURLs()
+	if len(skipped) != 1 || skipped[0] != "https://example.com/upstream" {
+		t.Errorf("expected external URL to be skipped, got %v", skipped)
+	}
+}
+
+func TestURLValidationRetries(t *testing.T) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		gets++
+		if gets == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	content := markparsr.NewMarkdownContent(fmt.Sprintf("See the [docs](%s/docs).\n", server.URL), markparsr.FormatDocument, nil)
+
+	validator := markparsr.NewURLValidator(content, markparsr.URLConfig{
+		Client:       server.Client(),
+		Retries:      2,
+		RetryBackoff: time.Millisecond,
+	})
+
+	if errors := validator.Validate(); len(errors) > 0 {
+		t.Fatalf("expected no validation errors, got %v", errors)
+	}
+	if gets != 2 {
+		t.Errorf("expected 2 GET requests, got %d", gets)
+	}
+}
+
+func TestURLRetryBudget(t *testing.T) {
+	tests := []struct {
+		name   string
+		head   []int
+		get    []int
+		heads  int
+		gets   int
+		broken bool
+	}{
+		{name: "head throttled", head: []int{429, 429, 429}, get: []int{200}, heads: 3, gets: 1},
+		{name: "head throttled and get throttled", head: []int{429}, get: []int{429}, heads: 3, gets: 1, broken: true},
+		{name: "head connection error", head: []int{0}, get: []int{200}, heads: 3, gets: 1},
+		{name: "head unavailable", head: []int{503, 200}, heads: 2},
+		{name: "head not allowed", head: []int{405}, get: []int{503, 503, 200}, heads: 1, gets: 3},
+		{name: "budget shared", head: []int{503, 501}, get: []int{503, 503, 200}, heads: 2, gets: 2, broken: true},
+		{name: "head not found", head: []int{404}, get: []int{200}, heads: 1, gets: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var heads, gets int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				statuses, count := tt.get, &gets
+				if r.Method == http.MethodHead {
+					statuses, count = tt.head, &heads
+				}
+				status := statuses[min(*count, len(statuses)-1)]
+				*count++
+				if status == 0 {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			content := markparsr.NewMarkdownContent(fmt.Sprintf("See the [docs](%s/docs).\n", server.URL), markparsr.FormatDocument, nil)
+			validator := markparsr.NewURLValidator(content, markparsr.URLConfig{
+				Client:       server.Client(),
+				Retries:      2,
+				RetryBackoff: time.Millisecond,
+			})
+
+			if errs := validator.Validate(); (len(errs) > 0) != tt.broken {
+				t.Errorf("expected broken=%v, got %v", tt.broken, errs)
+			}
+			if heads != tt.heads || gets != tt.gets {
+				t.Errorf("expected %d HEAD and %d GET requests, got %d and %d", tt.heads, tt.gets, heads, gets)
+			}
+		})
+	}
+}
+
+func TestURLHostLimitAcrossValidators(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, peak int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for module := range 2 {
+		var readme strings.Builder
+		for page := range 5 {
+			fmt.Fprintf(&readme, "- [page](%s/%d/%d)\n", server.URL, module, page)
+		}
+		validator := markparsr.NewURLValidator(markparsr.NewMarkdownContent(readme.String(), markparsr.FormatDocument, nil), markparsr.URLConfig{
+			Client:          server.Client(),
+			HostConcurrency: 1,
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errs := validator.Validate(); len(errs) > 0 {
+				t.Errorf("expected no validation errors, got %v", errs)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak != 1 {
+		t.Errorf("expected at most 1 concurrent request to the host across validators, got %d", peak)
+	}
+}
+
+func TestURLRedirectDropsAuthHeader(t *testing.T) {
+	var leaked string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("X-Api-Key")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	var sent string
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header.Get("X-Api-Key")
+		http.Redirect(w, r, target.URL+"/docs", http.StatusFound)
+	}))
+	defer origin.Close()
+
+	t.Setenv("MARKPARSR_TEST_TOKEN", "secret")
+	content := markparsr.NewMarkdownContent(fmt.Sprintf("See the [docs](%s/docs).\n", origin.URL), markparsr.FormatDocument, nil)
+
+	validator := markparsr.NewURLValidator(content, markparsr.URLConfig{
+		Client: origin.Client(),
+		Hosts: map[string]markparsr.HostConfig{
+			"127.0.0.1": {AuthHeader: "X-Api-Key", AuthEnv: "MARKPARSR_TEST_TOKEN"},
+		},
+	})
+
+	if errors := validator.Validate(); len(errors) > 0 {
+		t.Fatalf("expected no validation errors, got %v", errors)
+	}
+	if sent != "secret" {
+		t.Errorf("expected auth header on the original request, got %q", sent)
+	}
+	if leaked != "" {
+		t.Errorf("expected auth header to be dropped after a cross-host redirect, got %q", leaked)
+	}
+}
+
+func TestURLPatterns(t *testing.T) {
+	var mu sync.Mutex
+	var checked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			mu.Lock()
+			checked = append(checked, r.URL.Path)
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	readme := fmt.Sprintf(`# Module
+
+[docs](%[1]s/docs/intro) [draft](%[1]s/docs/draft) [api](%[1]s/api/v1) [blog](%[1]s/blog/2024)
+
+[tracker](https://tracker.internal.example/issue/1) [mirror](https://MIRROR.example.org/x)
+`, server.URL)
+
+	tests := []struct {
+		name      string
+		config    markparsr.URLConfig
+		checked   []string
+		forbidden []string
+	}{
+		{
+			name:    "include glob",
+			config:  markparsr.URLConfig{Include: []string{server.URL + "/docs/*"}},
+			checked: []string{"/docs/draft", "/docs/intro"},
+		},
+		{
+			name:    "exclude glob",
+			config:  markparsr.URLConfig{Include: []string{server.URL + "/*"}, Exclude: []string{"*/docs/dr?ft"}},
+			checked: []string{"/api/v1", "/blog/2024", "/docs/intro"},
+		},
+		{
+			name:    "regular expressions",
+			config:  markparsr.URLConfig{Include: []string{`re:/(api|blog)/`}, Exclude: []string{`re:/blog/\d{4}$`}},
+			checked: []string{"/api/v1"},
+		},
+		{
+			name: "forbidden hosts",
+			config: markparsr.URLConfig{
+				Include:        []string{server.URL + "/api/*"},
+				ForbiddenHosts: []string{"internal.example", "mirror.*"},
+			},
+			checked: []string{"/api/v1"},
+			forbidden: []string{
+				"URL links to forbidden host mirror.example.org: https://MIRROR.example.org/x",
+				"URL links to forbidden host tracker.internal.example: https://tracker.internal.example/issue/1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked = nil
+			tt.config.Client = server.Client()
+			validator := markparsr.NewURLValidator(markparsr.NewMarkdownContent(readme, markparsr.FormatDocument, nil), tt.config)
+
+			var forbidden []string
+			for _, err := range validator.Validate() {
+				var finding *markparsr.Finding
+				if !errors.As(err, &finding) || finding.Rule != markparsr.RuleForbiddenURL {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				forbidden = append(forbidden, finding.Message)
+			}
+			slices.Sort(checked)
+			slices.Sort(forbidden)
+
+			if !slices.Equal(checked, tt.checked) {
+				t.Errorf("checked = %q, want %q", checked, tt.checked)
+			}
+			if !slices.Equal(forbidden, tt.forbidden) {
+				t.Errorf("forbidden = %q, want %q", forbidden, tt.forbidden)
+			}
+		})
+	}
+
+	validator := markparsr.NewURLValidator(markparsr.NewMarkdownContent(readme, markparsr.FormatDocument, nil), markparsr.URLConfig{
+		Include: []string{"re:("},
+	})
+	if errs := validator.Validate(); len(errs) != 1 || !strings.Contains(errs[0].Error(), `invalid URL pattern "re:("`) {
+		t.Errorf("expected invalid pattern error, got %v", errs)
+	}
+}
+
+func TestExtractURLs(t *testing.T) {
+	content := markparsr.NewMarkdownContent(`# Module
+
+See the [docs](https://docs.example.com/guide), ![badge](https://img.shields.io/badge.svg) and <https://auto.example.com/>.
+
+Bare https://bare.example.com/page and the [docs again](https://docs.example.com/guide).
+
+<!-- [hidden](https://commented.example.com/) -->
+
+<!--
+<a href="https://commented-html.example.com/">hidden</a>
+-->
+
+<p align="center"><img src="https://html.example.com/logo.png" alt="logo"></p>
+
+Run `+"`curl https://inline.example.com/api`"+` or [mail](mailto:team@example.com) or [goals](./GOALS.md).
+
+`+"```hcl"+`
+source = "https://fenced.example.com/module.zip"
+`+"```"+`
+`, markparsr.FormatDocument, nil)
+
+	prose := []string{
+		"https://docs.example.com/guide",
+		"https://img.shields.io/badge.svg",
+		"https://auto.example.com/",
+		"https://bare.example.com/page",
+		"https://html.example.com/logo.png",
+	}
+
+	tests := []struct {
+		name        string
+		includeCode bool
+		want        []string
+	}{
+		{name: "prose only", want: prose},
+		{name: "with code", includeCode: true, want: append(slices.Clone(prose), "https://inline.example.com/api", "https://fenced.example.com/module.zip")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := content.ExtractURLs(tt.includeCode)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ExtractURLs(%v) = %q, want %q", tt.includeCode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadmeValidatorSkippedURLs(t *testing.T) {
+	t.Setenv("URL_CACHE_PATH", "")
+
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte("# Module\n\nSee the [docs](https://example.com/docs) and the [guide](https://example.com/guide).\n")},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+		markparsr.WithOfflineURLs(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	if skipped := validator.SkippedURLs(); len(skipped) != 0 {
+		t.Errorf("expected no skipped URLs before validating, got %v", skipped)
+	}
+
+	validator.Validate()
+
+	skipped := validator.SkippedURLs()
+	slices.Sort(skipped)
+	if want := []string{"https://example.com/docs", "https://example.com/guide"}; !slices.Equal(skipped, want) {
+		t.Errorf("SkippedURLs() = %q, want %q", skipped, want)
+	}
+}
+
+func TestURLHostConfigCase(t *testing.T) {
+	for _, env := range []string{"OFFLINE", "URL_CACHE_PATH", "URL_CACHE_TTL"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("MARKPARSR_TEST_TOKEN", "secret")
+
+	var mu sync.Mutex
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent = append(sent, r.Header.Get("X-Api-Key"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	docs := strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/docs"
+	fsys := fstest.MapFS{
+		"module/README.md": {Data: []byte(fmt.Sprintf("# Module\n\nSee the [docs](%s).\n", docs))},
+	}
+
+	validator, err := markparsr.NewReadmeValidator(
+		markparsr.WithFS(fsys),
+		markparsr.WithRelativeReadmePath("module/README.md"),
+		markparsr.WithHTTPClient(server.Client()),
+		markparsr.WithHostConfig("LocalHost", markparsr.HostConfig{AuthHeader: "X-Api-Key", AuthEnv: "MARKPARSR_TEST_TOKEN"}),
+		markparsr.WithHostConfig("*localhost*", markparsr.HostConfig{AuthHeader: "X-Other-Key", AuthEnv: "MARKPARSR_TEST_TOKEN"}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	validator.Validate()
+
+	if len(sent) == 0 || sent[0] != "secret" {
+		t.Errorf("expected the exact mixed-case host config to win for %s, got headers %q", docs, sent)
+	}
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudnationhq/az-cn-go-markparsr"
+)
+
+func TestUsageExamples(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		example string
+		want    []string
+	}{
+		{
+			name: "valid",
+			example: `module "storage" {
+  source  = "cloudnationhq/sa/azure"
+  version = "~> 2.0"
+
+  name = "example"
+}`,
+		},
+		{
+			name: "unknown and missing arguments",
+			example: `module "storage" {
+  source = "cloudnationhq/sa/azure"
+
+  location = "westeurope"
+}`,
+			want: []string{
+				"6 usage example does not set required variable 'name' in module.storage",
+				"9 usage example passes 'location' to module.storage but the module declares no such variable",
+			},
+		},
+		{
+			name: "helper module calls",
+			example: `module "naming" {
+  source = "cloudnationhq/naming/azure"
+
+  suffix = ["demo"]
+}
+
+module "storage" {
+  source = "cloudnationhq/sa/azure"
+
+  name = module.naming.storage_account.name
+}`,
+		},
+		{
+			name: "module under test matched by source",
+			dir:  "terraform-azure-sa",
+			example: `module "rg" {
+  source = "cloudnationhq/rg/azure"
+
+  name     = "rg-demo"
+  location = "westeurope"
+}
+
+module "storage" {
+  source = "cloudnationhq/sa/azure"
+
+  tags = {}
+}`,
+			want: []string{
+				"13 usage example does not set required variable 'name' in module.storage",
+			},
+		},
+		{
+			name: "syntax error",
+			example: `module "storage" {
+  source = "cloudnationhq/sa/azure"
+  name = "example
+}`,
+			want: []string{
+				"8 usage example has invalid HCL: Invalid multi-line string",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tt.dir
+			if dir == "" {
+				dir = "module"
+			}
+			readme := "# Module\n\n## Usage\n\n```hcl\n" + tt.example + "\n```\n\n## Notes\n\n```hcl\nnot = [valid\n```\n"
+			fsys := fstest.MapFS{
+				dir + "/README.md":    {Data: []byte(readme)},
+				dir + "/variables.tf": {Data: []byte("variable \"name\" {}\n\nvariable \"tags\" {\n  default = {}\n}\n")},
+			}
+
+			validator, err := markparsr.NewReadmeValidator(
+				markparsr.WithFS(fsys),
+				markparsr.WithRelativeReadmePath(dir+"/README.md"),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+
+			var got []string
+			for _, err := range validator.Validate() {
+				var finding *markparsr.Finding
+				if errors.As(err, &finding) && finding.Rule == markparsr.RuleUsage {
+					got = append(got, fmt.Sprintf("%d %s", finding.Line, finding.Message))
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("usage findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,69 +1,102 @@
 package markparsr
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
+	"path"
 	"path/filepath"
 )
 
 type FileValidator struct {
+	fsys            fs.FS
 	rootDir         string
 	requiredFiles   []string
 	additionalFiles []string
 }
 
 func NewFileValidator(readmePath string, modulePath string, additionalFiles []string) *FileValidator {
+	var absAdditionalFiles []string
+	for _, file := range additionalFiles {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(modulePath, file)
+		}
+		absAdditionalFiles = append(absAdditionalFiles, "/"+localPath(file))
+	}
+
+	return NewFileValidatorFS(localRoot(modulePath), localPath(readmePath), localPath(modulePath), absAdditionalFiles)
+}
+
+func NewFileValidatorFS(fsys fs.FS, readmePath string, modulePath string, additionalFiles []string) *FileValidator {
+	modulePath = cleanFSPath(modulePath)
+
 	requiredFiles := []string{
-		readmePath,
-		filepath.Join(modulePath, "outputs.tf"),
-		filepath.Join(modulePath, "variables.tf"),
-		filepath.Join(modulePath, "terraform.tf"),
+		cleanFSPath(readmePath),
+		path.Join(modulePath, "outputs.tf"),
+		path.Join(modulePath, "variables.tf"),
+		path.Join(modulePath, "terraform.tf"),
 	}
 
-	var absAdditionalFiles []string
+	var resolvedAdditionalFiles []string
 	for _, file := range additionalFiles {
-		if !filepath.IsAbs(file) {
-			absAdditionalFiles = append(absAdditionalFiles, filepath.Join(modulePath, file))
-		} else {
-			absAdditionalFiles = append(absAdditionalFiles, file)
+		file = filepath.ToSlash(file)
+		if !path.IsAbs(file) {
+			file = path.Join(modulePath, file)
 		}
+		resolvedAdditionalFiles = append(resolvedAdditionalFiles, cleanFSPath(file))
 	}
 
 	return &FileValidator{
+		fsys:            fsys,
 		rootDir:         modulePath,
 		requiredFiles:   requiredFiles,
-		additionalFiles: absAdditionalFiles,
+		additionalFiles: resolvedAdditionalFiles,
 	}
 }
 
 func (fv *FileValidator) Validate() []error {
+	return fv.ValidateContext(context.Background())
+}
+
+func (fv *FileValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
 	var allErrors []error
 
 	for _, filePath := range fv.requiredFiles {
-		if err := validateFile(filePath); err != nil {
-			allErrors = append(allErrors, fmt.Errorf("required %v", err))
+		if err := ctx.Err(); err != nil {
+			return append(allErrors, err)
+		}
+		if err := fv.validateFile(filePath); err != nil {
+			allErrors = append(allErrors, newFinding(RuleRequiredFile, path.Base(filePath), "required %w", err))
 		}
 	}
 
 	for _, filePath := range fv.additionalFiles {
-		if err := validateFile(filePath); err != nil {
-			allErrors = append(allErrors, fmt.Errorf("additional %v", err))
+		if err := ctx.Err(); err != nil {
+			return append(allErrors, err)
+		}
+		if err := fv.validateFile(filePath); err != nil {
+			allErrors = append(allErrors, newFinding(RuleAdditionalFile, path.Base(filePath), "additional %w", err))
 		}
 	}
 
 	return allErrors
 }
 
-func validateFile(filePath string) error {
-	fileInfo, err := os.Stat(filePath)
+func (fv *FileValidator) validateFile(filePath string) error {
+	fileInfo, err := fs.Stat(fv.fsys, filePath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("file does not exist: %s", filepath.Base(filePath))
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("file does not exist: %s", path.Base(filePath))
 		}
-		return fmt.Errorf("error accessing file: %s: %w", filepath.Base(filePath), err)
+		return fmt.Errorf("error accessing file: %s: %w", path.Base(filePath), err)
 	}
 	if fileInfo.Size() == 0 {
-		return fmt.Errorf("file is empty: %s", filepath.Base(filePath))
+		return fmt.Errorf("file is empty: %s", path.Base(filePath))
 	}
 	return nil
 }
@@ -0,0 +1,107 @@
+package markparsr
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	RuleMissingSection     = "missing-section"
+	RuleMisspelledSection  = "misspelled-section"
+	RuleRequiredFile       = "required-file"
+	RuleAdditionalFile     = "additional-file"
+	RuleBrokenURL          = "broken-url"
+	RuleForbiddenURL       = "forbidden-url"
+	RuleBrokenAnchor       = "broken-anchor"
+	RuleBrokenLink         = "broken-link"
+	RuleRegistryLink       = "registry-link"
+	RuleMissingInMarkdown  = "missing-in-markdown"
+	RuleMissingInTerraform = "missing-in-terraform"
+	RuleDocsBlock          = "docs-block"
+	RuleSectionOrder       = "section-order"
+	RuleDuplicate          = "duplicate"
+	RuleUsage              = "usage"
+	RuleError              = "error"
+)
+
+type Finding struct {
+	Rule    string
+	Item    string
+	File    string
+	Line    int
+	Message string
+	Fix     *Fix
+	err     error
+}
+
+type TextEdit struct {
+	Start   int
+	End     int
+	NewText string
+}
+
+type Fix struct {
+	Description string
+	Edits       []TextEdit
+}
+
+func newFinding(rule, item, format string, args ...any) *Finding {
+	err := fmt.Errorf(format, args...)
+	return &Finding{
+		Rule:    rule,
+		Item:    item,
+		Message: err.Error(),
+		err:     errors.Unwrap(err),
+	}
+}
+
+func (f *Finding) Error() string {
+	return f.Message
+}
+
+func (f *Finding) Unwrap() error {
+	return f.err
+}
+
+func findingKey(err error) (string, string) {
+	var finding *Finding
+	if errors.As(err, &finding) {
+		return finding.Rule, finding.Item
+	}
+	return RuleError, err.Error()
+}
+
+func (f *Finding) at(line int) *Finding {
+	f.Line = line
+	return f
+}
+
+func (f *Finding) withFix(fix *Fix) *Finding {
+	f.Fix = fix
+	return f
+}
+
+func SortFindings(errs []error) {
+	slices.SortStableFunc(errs, func(a, b error) int {
+		var fa, fb *Finding
+		okA, okB := errors.As(a, &fa), errors.As(b, &fb)
+		switch {
+		case okA && !okB:
+			return -1
+		case !okA && okB:
+			return 1
+		case !okA && !okB:
+			return strings.Compare(a.Error(), b.Error())
+		}
+		return cmp.Or(
+			strings.Compare(fa.File, fb.File),
+			cmp.Compare(fa.Line, fb.Line),
+			strings.Compare(fa.Rule, fb.Rule),
+			strings.Compare(fa.Item, fb.Item),
+			strings.Compare(fa.Message, fb.Message),
+		)
+	})
+}
@@ -0,0 +1,190 @@
+package markparsr
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+func ApplyFixes(content string, errs []error) (string, []error) {
+	var edits []TextEdit
+	var remaining []error
+	for _, err := range errs {
+		var finding *Finding
+		if !errors.As(err, &finding) || finding.Fix == nil || !fixApplies(content, finding.Fix, edits) {
+			remaining = append(remaining, err)
+			continue
+		}
+		edits = append(edits, finding.Fix.Edits...)
+	}
+
+	slices.Reverse(edits)
+	slices.SortStableFunc(edits, func(a, b TextEdit) int {
+		return cmp.Compare(b.Start, a.Start)
+	})
+	for _, edit := range edits {
+		content = content[:edit.Start] + edit.NewText + content[edit.End:]
+	}
+
+	return content, remaining
+}
+
+func writeReadme(fsys fs.FS, name, content, updated string, dryRun bool) error {
+	if dryRun {
+		fmt.Print(UnifiedDiff(name, content, updated))
+		return nil
+	}
+
+	writable, ok := fsys.(WriteFileFS)
+	if !ok {
+		return fmt.Errorf("cannot update %s: filesystem is read-only", name)
+	}
+	if err := writable.WriteFile(name, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func fixApplies(content string, fix *Fix, applied []TextEdit) bool {
+	for _, edit := range fix.Edits {
+		if edit.Start < 0 || edit.Start > edit.End || edit.End > len(content) {
+			return false
+		}
+		for _, other := range applied {
+			if edit.Start < other.End && other.Start < edit.End {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (mc *MarkdownContent) renameSectionFix(found, expected string) *Fix {
+	for _, heading := range mc.sectionHeadings {
+		if mc.sectionName(heading) != found {
+			continue
+		}
+		src, ok := mc.headingSources[heading]
+		if !ok {
+			return nil
+		}
+
+		edit := TextEdit{Start: src.start, End: src.end}
+		line := mc.data[src.start:src.end]
+		if offset := strings.Index(line, found); offset != -1 {
+			edit.Start += offset
+			edit.End = edit.Start + len(found)
+			edit.NewText = expected
+		} else {
+			edit.NewText = strings.Repeat("#", src.level) + " " + expected + line[len(strings.TrimRight(line, "\r\n")):]
+		}
+
+		return &Fix{
+			Description: fmt.Sprintf("rename section '%s' to '%s'", found, expected),
+			Edits:       []TextEdit{edit},
+		}
+	}
+	return nil
+}
+
+func (mc *MarkdownContent) deleteItemFix(sectionNames []string, name string) *Fix {
+	src, ok := mc.headingSources[mc.itemHeading(sectionNames, name)]
+	if !ok {
+		return nil
+	}
+
+	start := src.start
+	end, atHeading := mc.blockEnd(src)
+	if trimmed := len(strings.TrimRight(mc.data[:start], "\r\n")); !atHeading && trimmed > 0 {
+		start = trimmed + 1
+	}
+
+	return &Fix{
+		Description: fmt.Sprintf("delete stale entry %s", name),
+		Edits:       []TextEdit{{Start: start, End: end}},
+	}
+}
+
+func (mc *MarkdownContent) insertItemFix(sectionName, name, text string) *Fix {
+	headings := mc.matchSectionHeadings(sectionName)
+	if len(headings) == 0 {
+		return nil
+	}
+	section := headings[0]
+	src, ok := mc.headingSources[section]
+	if !ok {
+		return nil
+	}
+
+	text = relevelHeadings(text, mc.sectionLevel, mc.itemLevel)
+	fix := &Fix{Description: fmt.Sprintf("insert %s under '%s'", name, sectionName)}
+	for node := getNextSibling(section); node != nil; node = getNextSibling(node) {
+		h, ok := node.(*ast.Heading)
+		if !ok {
+			continue
+		}
+		if h.Level <= section.Level {
+			break
+		}
+		if existing, ok := mc.itemNameFromHeading(h); ok && h.Level == mc.itemLevel && existing > name {
+			if next, ok := mc.headingSources[h]; ok {
+				fix.Edits = []TextEdit{{Start: next.start, End: next.start, NewText: text + "\n\n"}}
+				return fix
+			}
+		}
+	}
+
+	end, atHeading := mc.blockEnd(src)
+	switch {
+	case atHeading:
+		text += "\n\n"
+	case strings.HasSuffix(mc.data[:end], "\n"):
+		text = "\n" + text + "\n"
+	default:
+		text = "\n\n" + text + "\n"
+	}
+	fix.Edits = []TextEdit{{Start: end, End: end, NewText: text}}
+	return fix
+}
+
+func (mc *MarkdownContent) itemHeading(sectionNames []string, name string) *ast.Heading {
+	for _, section := range mc.collectSectionHeadings(sectionNames) {
+		for node := getNextSibling(section); node != nil; node = getNextSibling(node) {
+			h, ok := node.(*ast.Heading)
+			if !ok {
+				continue
+			}
+			if h.Level <= section.Level {
+				break
+			}
+			if existing, ok := mc.itemNameFromHeading(h); ok && h.Level == mc.itemLevel && existing == name {
+				return h
+			}
+		}
+	}
+	return nil
+}
+
+func (mc *MarkdownContent) blockEnd(src sourceHeading) (int, bool) {
+	end := len(mc.data)
+	for _, marker := range mc.source.docsEnds {
+		if marker >= src.end {
+			end = marker
+			break
+		}
+	}
+	for _, heading := range mc.source.headings {
+		if heading.start >= src.end && heading.level <= src.level {
+			if heading.start < end {
+				return heading.start, true
+			}
+			break
+		}
+	}
+	return end, false
+}
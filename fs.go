@@ -0,0 +1,66 @@
+package markparsr
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type WriteFileFS interface {
+	fs.FS
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+type dirFS struct {
+	fs.FS
+	root string
+}
+
+func (d dirFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	return os.WriteFile(filepath.Join(d.root, filepath.FromSlash(name)), data, perm)
+}
+
+type fsFileReader struct {
+	fsys fs.FS
+}
+
+func (fr *fsFileReader) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(fr.fsys, name)
+}
+
+func localFS(osPath string) (fs.FS, string, error) {
+	absPath, err := filepath.Abs(osPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return localRoot(absPath), localPath(absPath), nil
+}
+
+func localRoot(osPath string) fs.FS {
+	if absPath, err := filepath.Abs(osPath); err == nil {
+		osPath = absPath
+	}
+	root := filepath.VolumeName(osPath) + string(filepath.Separator)
+	return dirFS{FS: os.DirFS(root), root: root}
+}
+
+func localPath(osPath string) string {
+	absPath, err := filepath.Abs(osPath)
+	if err != nil {
+		return cleanFSPath(osPath)
+	}
+	return cleanFSPath(strings.TrimPrefix(absPath, filepath.VolumeName(absPath)))
+}
+
+func cleanFSPath(name string) string {
+	name = strings.TrimPrefix(filepath.ToSlash(name), "/")
+	if name == "" {
+		return "."
+	}
+	return path.Clean(name)
+}
@@ -0,0 +1,189 @@
+package markparsr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type gitFS struct {
+	tree    *object.Tree
+	modTime time.Time
+}
+
+func NewGitFS(repoPath, revision string) (fs.FS, error) {
+	repo, err := git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository %s: %w", repoPath, err)
+	}
+
+	if revision == "" {
+		revision = "HEAD"
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %s: %w", revision, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load commit %s: %w", hash, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tree for commit %s: %w", hash, err)
+	}
+
+	return &gitFS{tree: tree, modTime: commit.Committer.When}, nil
+}
+
+func (gfs *gitFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if name == "." {
+		return &gitDir{fsys: gfs, tree: gfs.tree, info: gfs.dirInfo(".")}, nil
+	}
+
+	entry, err := gfs.tree.FindEntry(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	switch entry.Mode {
+	case filemode.Dir:
+		tree, err := gfs.tree.Tree(name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: gitPathError(err)}
+		}
+		return &gitDir{fsys: gfs, tree: tree, info: gfs.dirInfo(path.Base(name))}, nil
+	case filemode.Submodule:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	file, err := gfs.tree.TreeEntryFile(entry)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: gitPathError(err)}
+	}
+
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &gitFile{
+		Reader: bytes.NewReader([]byte(contents)),
+		info:   gfs.fileInfo(entry, file.Size),
+	}, nil
+}
+
+func (gfs *gitFS) dirInfo(name string) *gitFileInfo {
+	return &gitFileInfo{name: name, mode: fs.ModeDir | 0o555, modTime: gfs.modTime}
+}
+
+func (gfs *gitFS) fileInfo(entry *object.TreeEntry, size int64) *gitFileInfo {
+	mode := fs.FileMode(0o444)
+	switch entry.Mode {
+	case filemode.Executable:
+		mode = 0o555
+	case filemode.Symlink:
+		mode |= fs.ModeSymlink
+	case filemode.Dir:
+		mode = fs.ModeDir | 0o555
+	}
+	return &gitFileInfo{name: entry.Name, size: size, mode: mode, modTime: gfs.modTime}
+}
+
+func gitPathError(err error) error {
+	if errors.Is(err, object.ErrFileNotFound) || errors.Is(err, object.ErrDirectoryNotFound) ||
+		errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, plumbing.ErrObjectNotFound) {
+		return fs.ErrNotExist
+	}
+	return err
+}
+
+type gitFile struct {
+	*bytes.Reader
+	info *gitFileInfo
+}
+
+func (gf *gitFile) Stat() (fs.FileInfo, error) {
+	return gf.info, nil
+}
+
+func (gf *gitFile) Close() error {
+	return nil
+}
+
+type gitDir struct {
+	fsys   *gitFS
+	tree   *object.Tree
+	info   *gitFileInfo
+	offset int
+}
+
+func (gd *gitDir) Stat() (fs.FileInfo, error) {
+	return gd.info, nil
+}
+
+func (gd *gitDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: gd.info.name, Err: fs.ErrInvalid}
+}
+
+func (gd *gitDir) Close() error {
+	return nil
+}
+
+func (gd *gitDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	var result []fs.DirEntry
+	for ; gd.offset < len(gd.tree.Entries) && (n <= 0 || len(result) < n); gd.offset++ {
+		entry := &gd.tree.Entries[gd.offset]
+		if entry.Mode == filemode.Submodule {
+			continue
+		}
+
+		var size int64
+		if entry.Mode != filemode.Dir {
+			file, err := gd.tree.TreeEntryFile(entry)
+			if err != nil {
+				return result, err
+			}
+			size = file.Size
+		}
+		result = append(result, fs.FileInfoToDirEntry(gd.fsys.fileInfo(entry, size)))
+	}
+
+	if n > 0 && len(result) == 0 {
+		return nil, io.EOF
+	}
+	if result == nil {
+		result = []fs.DirEntry{}
+	}
+	return result, nil
+}
+
+type gitFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (gfi *gitFileInfo) Name() string       { return gfi.name }
+func (gfi *gitFileInfo) Size() int64        { return gfi.size }
+func (gfi *gitFileInfo) Mode() fs.FileMode  { return gfi.mode }
+func (gfi *gitFileInfo) ModTime() time.Time { return gfi.modTime }
+func (gfi *gitFileInfo) IsDir() bool        { return gfi.mode.IsDir() }
+func (gfi *gitFileInfo) Sys() any           { return nil }
@@ -1,9 +1,6 @@
 package markparsr
 
-import (
-	"fmt"
-	"strings"
-)
+import "strings"
 
 type defaultComparisonValidator struct{}
 
@@ -106,14 +103,16 @@ func compareTerraformAndMarkdown(tfItems, mdItems []string, itemType string) []e
 		if mdIndex.hasMatch(entry) {
 			continue
 		}
-		errors = append(errors, fmt.Errorf("%s in Terraform but missing in markdown: %s", itemType, entry.original))
+		errors = append(errors, newFinding(RuleMissingInMarkdown, itemType+"/"+entry.original,
+			"%s in Terraform but missing in markdown: %s", itemType, entry.original))
 	}
 
 	for _, entry := range mdIndex.items() {
 		if tfIndex.hasMatch(entry) {
 			continue
 		}
-		errors = append(errors, fmt.Errorf("%s in markdown but missing in Terraform: %s", itemType, entry.original))
+		errors = append(errors, newFinding(RuleMissingInTerraform, itemType+"/"+entry.original,
+			"%s in markdown but missing in Terraform: %s", itemType, entry.original))
 	}
 
 	return errors
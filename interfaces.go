@@ -1,6 +1,10 @@
 package markparsr
 
-import "github.com/hashicorp/hcl/v2"
+import (
+	"context"
+
+	"github.com/hashicorp/hcl/v2"
+)
 
 type FileReader interface {
 	ReadFile(path string) ([]byte, error)
@@ -40,4 +44,5 @@ type StringUtils interface {
 
 type Validator interface {
 	Validate() []error
+	ValidateContext(ctx context.Context) []error
 }
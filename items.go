@@ -1,5 +1,11 @@
 package markparsr
 
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
 type ItemValidator struct {
 	markdown  *MarkdownContent
 	terraform *TerraformContent
@@ -21,7 +27,15 @@ func NewItemValidator(markdown *MarkdownContent, terraform *TerraformContent, it
 }
 
 func (iv *ItemValidator) Validate() []error {
-	tfItems, err := iv.terraform.ExtractModuleItems(iv.blockType)
+	return iv.ValidateContext(context.Background())
+}
+
+func (iv *ItemValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	tfItems, err := iv.terraform.ExtractModuleItemsContext(ctx, iv.blockType)
 	if err != nil {
 		return []error{err}
 	}
@@ -39,5 +53,55 @@ func (iv *ItemValidator) Validate() []error {
 		return nil
 	}
 
-	return compareTerraformAndMarkdown(tfItems, mdItems, iv.itemType)
+	errs := iv.markdown.locateItems(compareTerraformAndMarkdown(tfItems, mdItems, iv.itemType))
+	if err := iv.attachFixes(ctx, errs); err != nil {
+		return append(errs, err)
+	}
+	return errs
+}
+
+func (iv *ItemValidator) attachFixes(ctx context.Context, errs []error) error {
+	if iv.markdown.format == FormatTable {
+		return nil
+	}
+
+	var blocks map[string]*ModuleBlock
+	for _, err := range errs {
+		var finding *Finding
+		if !errors.As(err, &finding) {
+			continue
+		}
+		_, name, _ := strings.Cut(finding.Item, "/")
+
+		switch finding.Rule {
+		case RuleMissingInTerraform:
+			finding.Fix = iv.markdown.deleteItemFix(iv.sections, name)
+		case RuleMissingInMarkdown:
+			if blocks == nil {
+				extracted, err := iv.terraform.ExtractModuleBlocksContext(ctx, iv.blockType)
+				if err != nil {
+					return err
+				}
+				blocks = make(map[string]*ModuleBlock)
+				for _, block := range extracted {
+					blocks[block.Name] = block
+				}
+			}
+			if block, ok := blocks[name]; ok {
+				section, text := iv.stub(block)
+				finding.Fix = iv.markdown.insertItemFix(section, name, text)
+			}
+		}
+	}
+	return nil
+}
+
+func (iv *ItemValidator) stub(block *ModuleBlock) (string, string) {
+	if iv.blockType == "output" {
+		return "Outputs", docsOutput(block)
+	}
+	if _, ok := block.Attributes["default"]; ok {
+		return "Optional Inputs", docsInput(block)
+	}
+	return "Required Inputs", docsInput(block)
 }
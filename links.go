@@ -0,0 +1,163 @@
+package markparsr
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+type RelativeLinkValidator struct {
+	fsys       fs.FS
+	readmePath string
+	repoRoot   string
+	content    *MarkdownContent
+}
+
+func NewRelativeLinkValidator(fsys fs.FS, readmePath, modulePath string, content *MarkdownContent) *RelativeLinkValidator {
+	return &RelativeLinkValidator{
+		fsys:       fsys,
+		readmePath: cleanFSPath(readmePath),
+		repoRoot:   repositoryRoot(fsys, cleanFSPath(modulePath)),
+		content:    content,
+	}
+}
+
+func repositoryRoot(fsys fs.FS, modulePath string) string {
+	for dir := modulePath; ; dir = path.Dir(dir) {
+		if _, err := fs.Stat(fsys, path.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		if dir == "." {
+			break
+		}
+	}
+	if _, local := fsys.(dirFS); local {
+		return modulePath
+	}
+	return "."
+}
+
+func (rlv *RelativeLinkValidator) Validate() []error {
+	return rlv.ValidateContext(context.Background())
+}
+
+func (rlv *RelativeLinkValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	seen := make(map[string]bool)
+	documents := make(map[string]*MarkdownContent)
+
+	for _, destination := range rlv.content.RelativeLinks() {
+		if err := ctx.Err(); err != nil {
+			return append(errs, err)
+		}
+		if seen[destination] {
+			continue
+		}
+		seen[destination] = true
+
+		target, fragment := rlv.resolve(destination)
+		if !fs.ValidPath(target) {
+			errs = append(errs, newFinding(RuleBrokenLink, destination, "relative link points outside the filesystem: %s", destination).at(rlv.content.linkLine(destination)))
+			continue
+		}
+
+		info, err := fs.Stat(rlv.fsys, target)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, newFinding(RuleBrokenLink, destination, "relative link target not found: %s", destination).at(rlv.content.linkLine(destination)))
+			} else {
+				errs = append(errs, newFinding(RuleBrokenLink, destination, "error accessing relative link target: %s: %w", destination, err).at(rlv.content.linkLine(destination)))
+			}
+			continue
+		}
+
+		if fragment == "" || info.IsDir() || !isMarkdownFile(target) {
+			continue
+		}
+
+		document, ok := documents[target]
+		if !ok {
+			data, err := fs.ReadFile(rlv.fsys, target)
+			if err != nil {
+				errs = append(errs, newFinding(RuleBrokenLink, destination, "error reading relative link target: %s: %w", destination, err).at(rlv.content.linkLine(destination)))
+				continue
+			}
+			document = NewMarkdownContent(string(data), FormatDocument, nil)
+			documents[target] = document
+		}
+
+		if !document.AnchorTargets()[fragment] {
+			errs = append(errs, newFinding(RuleBrokenAnchor, destination, "anchor not found in %s: #%s", path.Base(target), fragment).at(rlv.content.linkLine(destination)))
+		}
+	}
+
+	return errs
+}
+
+func (rlv *RelativeLinkValidator) resolve(destination string) (string, string) {
+	target, fragment, _ := strings.Cut(destination, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if decoded, err := url.PathUnescape(target); err == nil {
+		target = decoded
+	}
+
+	if strings.HasPrefix(target, "/") {
+		return path.Join(rlv.repoRoot, target), decodeFragment(fragment)
+	}
+	if target == "" {
+		return rlv.readmePath, decodeFragment(fragment)
+	}
+	return path.Join(path.Dir(rlv.readmePath), target), decodeFragment(fragment)
+}
+
+func (mc *MarkdownContent) RelativeLinks() []string {
+	var links []string
+	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+
+		var destination string
+		switch n := node.(type) {
+		case *ast.Link:
+			destination = string(n.Destination)
+		case *ast.Image:
+			destination = string(n.Destination)
+		default:
+			return ast.GoToNext
+		}
+
+		if isRelativeLink(destination) {
+			links = append(links, destination)
+		}
+		return ast.GoToNext
+	})
+	return links
+}
+
+func isRelativeLink(destination string) bool {
+	destination = strings.TrimSpace(destination)
+	if destination == "" || strings.HasPrefix(destination, "#") || strings.HasPrefix(destination, "//") {
+		return false
+	}
+
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == "" && parsed.Host == ""
+}
+
+func isMarkdownFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
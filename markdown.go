@@ -17,8 +17,12 @@ type MarkdownFormat string
 
 const (
 	FormatDocument MarkdownFormat = "document"
+	FormatTable    MarkdownFormat = "table"
+	FormatAuto     MarkdownFormat = "auto"
 )
 
+const markdownExtensions = parser.CommonExtensions | parser.AutoHeadingIDs
+
 var (
 	inputAnchorRe  = regexp.MustCompile(`(?i)<a\s+name="input_([^"\s]+)"`)
 	outputAnchorRe = regexp.MustCompile(`(?i)<a\s+name="output_([^"\s]+)"`)
@@ -30,16 +34,25 @@ type MarkdownContent struct {
 	sections         map[string]bool
 	format           MarkdownFormat
 	stringPool       *sync.Pool
+	mu               sync.Mutex
 	providerPrefixes []string
-	h2Headings       []*ast.Heading
+	sectionHeadings  []*ast.Heading
+	sectionLevel     int
+	itemLevel        int
 	sectionNames     []string
+	sectionAliases   map[string]string
 	sectionMatches   map[string][]*ast.Heading
 	anchorTypes      map[string]map[string]bool
+	source           *sourceIndex
+	headingSources   map[*ast.Heading]sourceHeading
 }
 
 func NewMarkdownContent(data string, format MarkdownFormat, providerPrefixes []string) *MarkdownContent {
-	extensions := parser.CommonExtensions | parser.AutoHeadingIDs
-	p := parser.NewWithExtensions(extensions)
+	return NewMarkdownContentWithLevels(data, format, providerPrefixes, 0, 0)
+}
+
+func NewMarkdownContentWithLevels(data string, format MarkdownFormat, providerPrefixes []string, sectionLevel, itemLevel int) *MarkdownContent {
+	p := parser.NewWithExtensions(markdownExtensions)
 	rootNode := markdown.Parse([]byte(data), p)
 
 	mc := &MarkdownContent{
@@ -55,26 +68,100 @@ func NewMarkdownContent(data string, format MarkdownFormat, providerPrefixes []s
 		sectionMatches:   make(map[string][]*ast.Heading),
 	}
 
+	mc.indexSource()
+	mc.deriveHeadingLevels(sectionLevel, itemLevel)
 	mc.indexHeadings()
 	mc.indexAnchors()
 
-	mc.format = FormatDocument
-	if format != "" && format != FormatDocument {
+	switch format {
+	case FormatDocument, FormatTable:
+		mc.format = format
+	case "", FormatAuto:
+		mc.format = mc.detectFormat()
+	default:
 		fmt.Printf("Markdown format '%s' is not supported; using document format\n", format)
+		mc.format = FormatDocument
 	}
 
 	return mc
 }
 
+func (mc *MarkdownContent) deriveHeadingLevels(sectionLevel, itemLevel int) {
+	if sectionLevel <= 0 {
+		sectionLevel = 2
+		if begin, end, ok := mc.docsBlock(); ok {
+			level := 0
+			for _, heading := range mc.source.headings {
+				if heading.start > begin && heading.start < end && (level == 0 || heading.level < level) {
+					level = heading.level
+				}
+			}
+			if level > 0 {
+				sectionLevel = level
+			}
+		}
+	}
+	if itemLevel <= 0 {
+		itemLevel = sectionLevel + 1
+	}
+	if itemLevel <= sectionLevel {
+		fmt.Printf("Item heading level %d must be deeper than section heading level %d; using %d\n", itemLevel, sectionLevel, sectionLevel+1)
+		itemLevel = sectionLevel + 1
+	}
+	mc.sectionLevel, mc.itemLevel = sectionLevel, itemLevel
+}
+
+func (mc *MarkdownContent) detectFormat() MarkdownFormat {
+	_, _, scoped := mc.docsBlock()
+
+	headingItems, tableItems := 0, 0
+	for _, heading := range mc.sectionHeadings {
+		if scoped && !mc.inDocsBlock(heading) {
+			continue
+		}
+		if name := strings.ToLower(mc.extractText(heading)); !strings.Contains(name, "input") && !strings.Contains(name, "output") {
+			continue
+		}
+
+		for node := getNextSibling(heading); node != nil; node = getNextSibling(node) {
+			if h, ok := node.(*ast.Heading); ok {
+				if h.Level <= heading.Level {
+					break
+				}
+				if h.Level == mc.itemLevel {
+					headingItems++
+				}
+			}
+			if table, ok := node.(*ast.Table); ok {
+				tableItems += len(mc.tableRows(table))
+			}
+		}
+	}
+
+	switch {
+	case headingItems > 0 && tableItems > 0:
+		format := FormatDocument
+		if tableItems > headingItems {
+			format = FormatTable
+		}
+		fmt.Printf("README mixes document and table styles (%d item headings, %d table rows); using %s format\n", headingItems, tableItems, format)
+		return format
+	case tableItems > 0:
+		return FormatTable
+	}
+	return FormatDocument
+}
+
 func (mc *MarkdownContent) indexHeadings() {
+	var headings []*ast.Heading
 	var names []string
 	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
 		if !entering {
 			return ast.GoToNext
 		}
-		if heading, ok := node.(*ast.Heading); ok && heading.Level == 2 {
-			mc.h2Headings = append(mc.h2Headings, heading)
-			text := strings.TrimSpace(mc.extractText(heading))
+		if heading, ok := node.(*ast.Heading); ok && mc.isSectionHeading(heading) {
+			headings = append(headings, heading)
+			text := mc.sectionName(heading)
 			if text != "" {
 				names = append(names, text)
 			}
@@ -82,7 +169,42 @@ func (mc *MarkdownContent) indexHeadings() {
 		}
 		return ast.GoToNext
 	})
-	mc.sectionNames = names
+	mc.sectionHeadings, mc.sectionNames = headings, names
+}
+
+func (mc *MarkdownContent) indexSectionAliases(schema []SectionSchema) {
+	aliases := make(map[string]string)
+	for _, section := range schema {
+		for _, alias := range section.Aliases {
+			aliases[strings.ToLower(strings.TrimSpace(alias))] = section.Name
+		}
+	}
+
+	mc.mu.Lock()
+	mc.sectionAliases = aliases
+	mc.sections = make(map[string]bool)
+	mc.sectionMatches = make(map[string][]*ast.Heading)
+	mc.mu.Unlock()
+	mc.indexHeadings()
+}
+
+func (mc *MarkdownContent) sectionName(heading *ast.Heading) string {
+	text := strings.TrimSpace(mc.extractText(heading))
+	if name, ok := mc.sectionAliases[strings.ToLower(text)]; ok {
+		return name
+	}
+	return text
+}
+
+func (mc *MarkdownContent) isSectionHeading(heading *ast.Heading) bool {
+	_, _, hasBlock := mc.docsBlock()
+	if hasBlock && mc.inDocsBlock(heading) {
+		return heading.Level == mc.sectionLevel
+	}
+	if heading.Level == 2 {
+		return true
+	}
+	return !hasBlock && heading.Level == mc.sectionLevel && resemblesGeneratedSection(mc.extractText(heading))
 }
 
 func (mc *MarkdownContent) indexAnchors() {
@@ -97,7 +219,7 @@ func (mc *MarkdownContent) indexAnchors() {
 	}
 
 	for _, def := range defs {
-		matches := def.re.FindAllStringSubmatch(mc.data, -1)
+		matches := def.re.FindAllStringSubmatch(mc.generatedData(), -1)
 		for _, match := range matches {
 			if len(match) < 2 {
 				continue
@@ -119,12 +241,17 @@ func (mc *MarkdownContent) GetContent() string {
 }
 
 func (mc *MarkdownContent) HasSection(sectionName string) bool {
-	if found, exists := mc.sections[sectionName]; exists {
+	mc.mu.Lock()
+	found, exists := mc.sections[sectionName]
+	mc.mu.Unlock()
+	if exists {
 		return found
 	}
 
-	found := len(mc.matchSectionHeadings(sectionName)) > 0
+	found = len(mc.matchSectionHeadings(sectionName)) > 0
+	mc.mu.Lock()
 	mc.sections[sectionName] = found
+	mc.mu.Unlock()
 	return found
 }
 
@@ -133,6 +260,9 @@ func (mc *MarkdownContent) GetAllSections() []string {
 }
 
 func (mc *MarkdownContent) ExtractSectionItems(sectionNames ...string) []string {
+	if mc.format == FormatTable {
+		return mc.extractTableSectionItems(sectionNames...)
+	}
 	return mc.extractDocumentSectionItems(sectionNames...)
 }
 
@@ -142,19 +272,29 @@ func (mc *MarkdownContent) matchSectionHeadings(sectionName string) []*ast.Headi
 		return nil
 	}
 
-	if cached, ok := mc.sectionMatches[key]; ok {
+	mc.mu.Lock()
+	cached, ok := mc.sectionMatches[key]
+	mc.mu.Unlock()
+	if ok {
 		return cached
 	}
 
+	_, _, scoped := mc.docsBlock()
+	scoped = scoped && isGeneratedSection(sectionName)
+
 	var matches []*ast.Heading
-	for _, heading := range mc.h2Headings {
-		text := strings.TrimSpace(mc.extractText(heading))
-		if matchesSectionName(text, sectionName) {
+	for _, heading := range mc.sectionHeadings {
+		if scoped && !mc.inDocsBlock(heading) {
+			continue
+		}
+		if matchesSectionName(mc.sectionName(heading), sectionName) {
 			matches = append(matches, heading)
 		}
 	}
 
+	mc.mu.Lock()
 	mc.sectionMatches[key] = matches
+	mc.mu.Unlock()
 	return matches
 }
 
@@ -267,7 +407,7 @@ func (mc *MarkdownContent) expectedAnchorType(sectionNames []string) string {
 }
 
 func (mc *MarkdownContent) extractAnchoredItems(re *regexp.Regexp) []string {
-	matches := re.FindAllStringSubmatch(mc.data, -1)
+	matches := re.FindAllStringSubmatch(mc.generatedData(), -1)
 	var items []string
 	for _, match := range matches {
 		if len(match) < 2 {
@@ -289,7 +429,7 @@ func (mc *MarkdownContent) itemsUnderHeading(heading *ast.Heading) []string {
 			if h.Level <= heading.Level {
 				break
 			}
-			if h.Level == 3 {
+			if h.Level == mc.itemLevel {
 				if name, ok := mc.itemNameFromHeading(h); ok {
 					items = append(items, name)
 				}
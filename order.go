@@ -0,0 +1,180 @@
+package markparsr
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+)
+
+const (
+	SectionDocsBlock   = "terraform-docs"
+	SectionTitle       = "title"
+	SectionDescription = "description"
+)
+
+type SectionOrderValidator struct {
+	content *MarkdownContent
+	order   []string
+}
+
+type orderedSection struct {
+	name   string
+	offset int
+	line   int
+	rank   int
+}
+
+func NewSectionOrderValidator(content *MarkdownContent, order []string) *SectionOrderValidator {
+	return &SectionOrderValidator{content: content, order: order}
+}
+
+func (ov *SectionOrderValidator) Validate() []error {
+	return ov.ValidateContext(context.Background())
+}
+
+func (ov *SectionOrderValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+	if len(ov.order) == 0 {
+		return nil
+	}
+
+	sections := ov.sections()
+	if len(sections) < 2 {
+		return nil
+	}
+
+	inOrder := longestOrderedRun(sections)
+	expected := slices.Clone(sections)
+	slices.SortStableFunc(expected, func(a, b orderedSection) int {
+		return cmp.Compare(a.rank, b.rank)
+	})
+
+	var errs []error
+	for i, section := range sections {
+		if inOrder[i] {
+			continue
+		}
+
+		position := slices.IndexFunc(expected, func(s orderedSection) bool { return s.offset == section.offset })
+		neighbour := "before " + sectionLabel(expected[1].name)
+		if position > 0 {
+			neighbour = "after " + sectionLabel(expected[position-1].name)
+		}
+
+		errs = append(errs, newFinding(RuleSectionOrder, section.name,
+			"%s is out of order: expected at position %d, %s", sectionLabel(section.name), position+1, neighbour).at(section.line))
+	}
+	return errs
+}
+
+func (ov *SectionOrderValidator) sections() []orderedSection {
+	rank := func(name string) int {
+		return slices.IndexFunc(ov.order, func(entry string) bool {
+			return strings.EqualFold(strings.TrimSpace(entry), name)
+		})
+	}
+
+	var sections []orderedSection
+	begin, _, hasBlock := ov.content.docsBlock()
+	blockRank := rank(SectionDocsBlock)
+	if hasBlock && blockRank != -1 {
+		sections = append(sections, orderedSection{
+			name:   SectionDocsBlock,
+			offset: begin,
+			line:   ov.content.source.lineAt(begin),
+			rank:   blockRank,
+		})
+	}
+
+	title, hasTitle := ov.content.titleSource()
+	if r := rank(SectionTitle); hasTitle && r != -1 {
+		sections = append(sections, orderedSection{name: SectionTitle, offset: title.start, line: title.line, rank: r})
+	}
+	if r := rank(SectionDescription); r != -1 {
+		if offset, ok := ov.content.descriptionOffset(title.end); ok {
+			sections = append(sections, orderedSection{name: SectionDescription, offset: offset, line: ov.content.source.lineAt(offset), rank: r})
+		}
+	}
+
+	for _, heading := range ov.content.sectionHeadings {
+		if blockRank != -1 && ov.content.inDocsBlock(heading) {
+			continue
+		}
+		name := ov.content.sectionName(heading)
+		r := rank(name)
+		src, ok := ov.content.headingSources[heading]
+		if r == -1 || !ok {
+			continue
+		}
+		sections = append(sections, orderedSection{name: name, offset: src.start, line: src.line, rank: r})
+	}
+
+	slices.SortFunc(sections, func(a, b orderedSection) int {
+		return cmp.Compare(a.offset, b.offset)
+	})
+	return sections
+}
+
+func longestOrderedRun(sections []orderedSection) []bool {
+	length := make([]int, len(sections))
+	prev := make([]int, len(sections))
+	best := 0
+	for i := range sections {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if sections[j].rank <= sections[i].rank && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if length[i] > length[best] {
+			best = i
+		}
+	}
+
+	inOrder := make([]bool, len(sections))
+	for i := best; i != -1; i = prev[i] {
+		inOrder[i] = true
+	}
+	return inOrder
+}
+
+func (mc *MarkdownContent) titleSource() (sourceHeading, bool) {
+	for _, heading := range mc.source.headings {
+		if heading.level == 1 {
+			return heading, true
+		}
+	}
+	return sourceHeading{}, false
+}
+
+func (mc *MarkdownContent) descriptionOffset(start int) (int, bool) {
+	end := len(mc.data)
+	for _, heading := range mc.source.headings {
+		if heading.start >= start {
+			end = heading.start
+			break
+		}
+	}
+	if begin, _, ok := mc.docsBlock(); ok && begin >= start && begin < end {
+		end = begin
+	}
+
+	text := mc.data[start:end]
+	offset := start + len(text) - len(strings.TrimLeft(text, " \t\r\n"))
+	return offset, offset < end
+}
+
+func sectionLabel(name string) string {
+	switch name {
+	case SectionDocsBlock:
+		return "the terraform-docs block"
+	case SectionTitle:
+		return "the title"
+	case SectionDescription:
+		return "the description"
+	}
+	return "section '" + name + "'"
+}
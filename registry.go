@@ -0,0 +1,193 @@
+package markparsr
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+type ResourceLink struct {
+	Name        string
+	Destination string
+	Label       string
+}
+
+type RegistryLinkValidator struct {
+	markdown  *MarkdownContent
+	terraform *TerraformContent
+}
+
+func NewRegistryLinkValidator(markdown *MarkdownContent, terraform *TerraformContent) *RegistryLinkValidator {
+	return &RegistryLinkValidator{
+		markdown:  markdown,
+		terraform: terraform,
+	}
+}
+
+func (rlv *RegistryLinkValidator) Validate() []error {
+	return rlv.ValidateContext(context.Background())
+}
+
+func (rlv *RegistryLinkValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	links := rlv.markdown.ResourceLinks()
+	if len(links) == 0 {
+		return nil
+	}
+
+	providers, err := rlv.terraform.ExtractProviderRequirementsContext(ctx)
+	if err != nil {
+		return []error{err}
+	}
+
+	tfResources, tfDataSources, err := rlv.terraform.ExtractResourcesAndDataSourcesContext(ctx)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, link := range links {
+		dataSource := link.Label == "data source"
+		if link.Label == "" {
+			dataSource = declaresOnly(link.Name, tfDataSources, tfResources)
+		}
+		if finding := checkRegistryLink(link, dataSource, providers); finding != nil {
+			errs = append(errs, finding.at(rlv.markdown.linkLine(link.Destination)))
+		}
+	}
+	return errs
+}
+
+func declaresOnly(name string, in, notIn []string) bool {
+	resourceType, _, _ := strings.Cut(name, ".")
+	return (slices.Contains(in, name) || slices.Contains(in, resourceType)) &&
+		!slices.Contains(notIn, name) && !slices.Contains(notIn, resourceType)
+}
+
+func checkRegistryLink(link ResourceLink, dataSource bool, providers map[string]ProviderRequirement) *Finding {
+	resourceType, _, _ := strings.Cut(link.Name, ".")
+	providerName, slug, ok := strings.Cut(resourceType, "_")
+	if !ok {
+		return nil
+	}
+
+	parsed, err := url.Parse(link.Destination)
+	if err != nil || !strings.EqualFold(parsed.Host, "registry.terraform.io") {
+		return newFinding(RuleRegistryLink, link.Name, "resource link for %s does not point to the Terraform registry: %s", link.Name, link.Destination)
+	}
+
+	// /providers/<namespace>/<provider>/<version>/docs/<kind>/<slug>
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) != 7 || parts[0] != "providers" || parts[4] != "docs" {
+		return newFinding(RuleRegistryLink, link.Name, "resource link for %s is not a registry documentation page: %s", link.Name, link.Destination)
+	}
+	namespace, linkProvider, kind, linkSlug := parts[1], parts[2], parts[5], parts[6]
+
+	if !strings.EqualFold(linkProvider, providerName) {
+		return newFinding(RuleRegistryLink, link.Name, "resource link for %s points to provider %s (expected %s): %s", link.Name, linkProvider, providerName, link.Destination)
+	}
+
+	expectedNamespace := "hashicorp"
+	if requirement, ok := providers[providerName]; ok && requirement.Namespace() != "" {
+		expectedNamespace = requirement.Namespace()
+	}
+	if !strings.EqualFold(namespace, expectedNamespace) {
+		return newFinding(RuleRegistryLink, link.Name, "resource link for %s points to namespace %s (expected %s): %s", link.Name, namespace, expectedNamespace, link.Destination)
+	}
+
+	expectedKind := "resources"
+	if dataSource {
+		expectedKind = "data-sources"
+	}
+	if kind != expectedKind {
+		return newFinding(RuleRegistryLink, link.Name, "resource link for %s points to /%s/ (expected /%s/): %s", link.Name, kind, expectedKind, link.Destination)
+	}
+
+	if linkSlug != slug {
+		return newFinding(RuleRegistryLink, link.Name, "resource link for %s points to %s (expected %s): %s", link.Name, linkSlug, slug, link.Destination)
+	}
+
+	return nil
+}
+
+func (mc *MarkdownContent) ResourceLinks() []ResourceLink {
+	headings := mc.collectSectionHeadings([]string{"Resources"})
+
+	var nodes []ast.Node
+	labelledOnly := len(headings) == 0
+	if labelledOnly {
+		nodes = []ast.Node{mc.rootNode}
+	}
+	for _, heading := range headings {
+		for node := getNextSibling(heading); node != nil; node = getNextSibling(node) {
+			if h, ok := node.(*ast.Heading); ok && h.Level <= heading.Level {
+				break
+			}
+			nodes = append(nodes, node)
+		}
+	}
+
+	var links []ResourceLink
+	for _, node := range nodes {
+		ast.WalkFunc(node, func(n ast.Node, entering bool) ast.WalkStatus {
+			if !entering {
+				return ast.GoToNext
+			}
+			link, ok := n.(*ast.Link)
+			if !ok {
+				return ast.GoToNext
+			}
+
+			name := strings.TrimSpace(mc.extractText(link))
+			if !mc.hasProviderPrefix(name) {
+				return ast.SkipChildren
+			}
+			label := mc.resourceLinkLabel(link)
+			if labelledOnly && label == "" {
+				return ast.SkipChildren
+			}
+			links = append(links, ResourceLink{
+				Name:        name,
+				Destination: string(link.Destination),
+				Label:       label,
+			})
+			return ast.SkipChildren
+		})
+	}
+
+	return links
+}
+
+func (mc *MarkdownContent) resourceLinkLabel(link *ast.Link) string {
+	if cell, ok := link.GetParent().(*ast.TableCell); ok {
+		next := getNextSibling(cell)
+		if next == nil {
+			return ""
+		}
+		switch label := strings.ToLower(strings.TrimSpace(mc.extractText(next))); label {
+		case "data source", "resource":
+			return label
+		}
+		return ""
+	}
+
+	next := getNextSibling(link)
+	if next == nil {
+		return ""
+	}
+
+	label := strings.ToLower(mc.extractText(next))
+	switch {
+	case strings.Contains(label, "(data source)"):
+		return "data source"
+	case strings.Contains(label, "(resource)"):
+		return "resource"
+	}
+	return ""
+}
@@ -1,32 +1,110 @@
 package markparsr
 
 import (
-	"fmt"
+	"context"
 	"slices"
 	"strings"
 )
 
+type SectionRule string
+
+const (
+	SectionRequired    SectionRule = "required"
+	SectionOptional    SectionRule = "optional"
+	SectionConditional SectionRule = "conditional"
+)
+
+type SectionCondition func(ctx context.Context, terraform *TerraformContent) (bool, error)
+
+type SectionSchema struct {
+	Name    string
+	Rule    SectionRule
+	Level   int
+	Aliases []string
+	When    SectionCondition
+}
+
+func DefaultSectionSchema(format MarkdownFormat) []SectionSchema {
+	schema := []SectionSchema{
+		{Name: "Resources", Rule: SectionRequired},
+		{Name: "Providers", Rule: SectionRequired},
+		{Name: "Requirements", Rule: SectionRequired},
+	}
+
+	if format == FormatTable {
+		schema = append(schema,
+			SectionSchema{Name: "Inputs", Rule: SectionConditional, When: WhenDeclared("variable")},
+		)
+	} else {
+		schema = append(schema,
+			SectionSchema{Name: "Required Inputs", Rule: SectionConditional, When: WhenVariables(true)},
+			SectionSchema{Name: "Optional Inputs", Rule: SectionConditional, When: WhenVariables(false)},
+		)
+	}
+
+	return append(schema, SectionSchema{Name: "Outputs", Rule: SectionConditional, When: WhenDeclared("output")})
+}
+
+func WhenDeclared(blockType string) SectionCondition {
+	return func(ctx context.Context, terraform *TerraformContent) (bool, error) {
+		if blockType == "resource" || blockType == "data" {
+			resources, dataSources, err := terraform.ExtractResourcesAndDataSourcesContext(ctx)
+			if blockType == "data" {
+				return len(dataSources) > 0, err
+			}
+			return len(resources) > 0, err
+		}
+
+		items, err := terraform.ExtractModuleItemsContext(ctx, blockType)
+		return len(items) > 0, err
+	}
+}
+
+func WhenVariables(required bool) SectionCondition {
+	return func(ctx context.Context, terraform *TerraformContent) (bool, error) {
+		variables, err := terraform.ExtractModuleBlocksContext(ctx, "variable")
+		if err != nil {
+			return false, err
+		}
+		for _, variable := range variables {
+			if _, hasDefault := variable.Attributes["default"]; hasDefault != required {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
 type SectionValidator struct {
 	content            *MarkdownContent
-	requiredSections   []string
+	terraform          *TerraformContent
+	schema             []SectionSchema
 	additionalSections []string
 }
 
 func NewSectionValidator(content *MarkdownContent, additionalSections []string) *SectionValidator {
-	requiredSections := []string{
-		"Resources", "Providers", "Requirements",
-	}
-
-	requiredSections = append(requiredSections, "Required Inputs", "Optional Inputs", "Outputs")
+	return NewSectionValidatorWithSchema(content, nil, DefaultSectionSchema(content.format), additionalSections)
+}
 
+func NewSectionValidatorWithSchema(content *MarkdownContent, terraform *TerraformContent, schema []SectionSchema, additionalSections []string) *SectionValidator {
+	content.indexSectionAliases(schema)
 	return &SectionValidator{
 		content:            content,
-		requiredSections:   requiredSections,
+		terraform:          terraform,
+		schema:             schema,
 		additionalSections: additionalSections,
 	}
 }
 
 func (sv *SectionValidator) Validate() []error {
+	return sv.ValidateContext(context.Background())
+}
+
+func (sv *SectionValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
 	var allErrors []error
 	foundSections := sv.content.GetAllSections()
 
@@ -34,26 +112,41 @@ func (sv *SectionValidator) Validate() []error {
 
 	missingSections := make(map[string]bool)
 
-	for _, requiredSection := range sv.requiredSections {
-		if slices.Contains(foundSections, requiredSection) {
-			handledSections[requiredSection] = true
+	for _, section := range sv.schema {
+		level := section.Level
+		if level == 0 {
+			level = sv.content.sectionLevel
+		}
+		candidates := sv.content.headingNames(level, isGeneratedSection(section.Name))
+		if slices.Contains(candidates, section.Name) {
+			handledSections[section.Name] = true
 			continue
 		}
 
 		misspellingFound := false
-		for _, foundSection := range foundSections {
-			if !handledSections[foundSection] && isSimilarSection(foundSection, requiredSection) {
-				allErrors = append(allErrors, fmt.Errorf("section '%s' appears to be misspelled (should be '%s')",
-					foundSection, requiredSection))
+		for _, foundSection := range candidates {
+			if !handledSections[foundSection] && isSimilarSection(foundSection, section.Name) {
+				allErrors = append(allErrors, newFinding(RuleMisspelledSection, foundSection,
+					"section '%s' appears to be misspelled (should be '%s')", foundSection, section.Name).
+					at(sv.content.sectionLine(foundSection)).
+					withFix(sv.content.renameSectionFix(foundSection, section.Name)))
 				handledSections[foundSection] = true
 				misspellingFound = true
 				break
 			}
 		}
+		if misspellingFound {
+			continue
+		}
 
-		if !misspellingFound {
-			missingSections[requiredSection] = true
-			allErrors = append(allErrors, fmt.Errorf("required section missing: '%s'", requiredSection))
+		required, err := sv.required(ctx, section)
+		if err != nil {
+			allErrors = append(allErrors, err)
+			continue
+		}
+		if required {
+			missingSections[section.Name] = true
+			allErrors = append(allErrors, newFinding(RuleMissingSection, section.Name, "required section missing: '%s'", section.Name))
 		}
 	}
 
@@ -62,16 +155,22 @@ func (sv *SectionValidator) Validate() []error {
 			continue
 		}
 
-		if slices.Contains(foundSections, additionalSection) {
+		candidates := foundSections
+		if isGeneratedSection(additionalSection) {
+			candidates = sv.content.generatedSectionNames()
+		}
+		if slices.Contains(candidates, additionalSection) {
 			handledSections[additionalSection] = true
 			continue
 		}
 
 		misspellingFound := false
-		for _, foundSection := range foundSections {
+		for _, foundSection := range candidates {
 			if !handledSections[foundSection] && isSimilarSection(foundSection, additionalSection) {
-				allErrors = append(allErrors, fmt.Errorf("section '%s' appears to be misspelled (should be '%s')",
-					foundSection, additionalSection))
+				allErrors = append(allErrors, newFinding(RuleMisspelledSection, foundSection,
+					"section '%s' appears to be misspelled (should be '%s')", foundSection, additionalSection).
+					at(sv.content.sectionLine(foundSection)).
+					withFix(sv.content.renameSectionFix(foundSection, additionalSection)))
 				handledSections[foundSection] = true
 				misspellingFound = true
 				break
@@ -79,12 +178,25 @@ func (sv *SectionValidator) Validate() []error {
 		}
 
 		if !misspellingFound {
-			allErrors = append(allErrors, fmt.Errorf("additional section missing: '%s'", additionalSection))
+			allErrors = append(allErrors, newFinding(RuleMissingSection, additionalSection, "additional section missing: '%s'", additionalSection))
 		}
 	}
 	return allErrors
 }
 
+func (sv *SectionValidator) required(ctx context.Context, section SectionSchema) (bool, error) {
+	switch section.Rule {
+	case SectionOptional:
+		return false, nil
+	case SectionConditional:
+		if section.When == nil || sv.terraform == nil {
+			return true, nil
+		}
+		return section.When(ctx, sv.terraform)
+	}
+	return true, nil
+}
+
 func isSimilarSection(found, expected string) bool {
 	if found == expected {
 		return true
@@ -0,0 +1,213 @@
+package markparsr
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+type sourceHeading struct {
+	level int
+	text  string
+	line  int
+	start int
+	end   int
+}
+
+type sourceFence struct {
+	info    string
+	line    int
+	start   int
+	content string
+}
+
+type sourceIndex struct {
+	data       string
+	lineStarts []int
+	headings   []sourceHeading
+	fences     []sourceFence
+	docsBegins []int
+	docsEnds   []int
+}
+
+func (idx *sourceIndex) lineAt(offset int) int {
+	return sort.Search(len(idx.lineStarts), func(i int) bool {
+		return idx.lineStarts[i] > offset
+	})
+}
+
+func (idx *sourceIndex) lineEnd(line int) int {
+	if line < len(idx.lineStarts) {
+		return idx.lineStarts[line]
+	}
+	return len(idx.data)
+}
+
+func (idx *sourceIndex) find(text string, from int) (int, int) {
+	line, _, _ := strings.Cut(text, "\n")
+	if strings.TrimSpace(line) == "" {
+		return -1, 0
+	}
+	if at := strings.Index(idx.data[from:], text); at != -1 {
+		return from + at, len(text)
+	}
+	if at := strings.Index(idx.data[from:], line); at != -1 {
+		return from + at, len(line)
+	}
+	return -1, 0
+}
+
+func (idx *sourceIndex) locateHeading(heading *ast.Heading, text string, from int) (sourceHeading, bool) {
+	first := ""
+	ast.WalkFunc(heading, func(node ast.Node, entering bool) ast.WalkStatus {
+		if leaf := node.AsLeaf(); leaf != nil && entering && len(bytes.TrimSpace(leaf.Literal)) > 0 {
+			first = string(leaf.Literal)
+			return ast.Terminate
+		}
+		return ast.GoToNext
+	})
+
+	for offset, _ := idx.find(first, from); offset != -1; offset, _ = idx.find(first, offset+1) {
+		line := idx.lineAt(offset)
+		start := idx.lineStarts[line-1]
+		prefix := strings.TrimLeft(idx.data[start:offset], " \t>*+-0123456789.)")
+		src := sourceHeading{level: heading.Level, text: text, line: line, start: start, end: idx.lineEnd(line)}
+
+		if hashes := strings.TrimLeft(prefix, "#"); len(prefix)-len(hashes) == heading.Level && strings.TrimSpace(hashes) == "" {
+			return src, true
+		}
+		if prefix != "" {
+			continue
+		}
+		for next := line + 1; next <= len(idx.lineStarts); next++ {
+			text := strings.TrimLeft(idx.data[idx.lineStarts[next-1]:idx.lineEnd(next)], " \t>")
+			if isSetextUnderline(text) {
+				src.end = idx.lineEnd(next)
+				return src, true
+			}
+			if strings.TrimSpace(text) == "" {
+				break
+			}
+		}
+	}
+	return sourceHeading{}, false
+}
+
+func isSetextUnderline(line string) bool {
+	line = strings.TrimSpace(line)
+	return line != "" && strings.Trim(line, line[:1]) == "" && (line[0] == '=' || line[0] == '-')
+}
+
+func (mc *MarkdownContent) indexSource() {
+	idx := &sourceIndex{data: mc.data, lineStarts: []int{0}}
+	for i := 0; i < len(mc.data)-1; i++ {
+		if mc.data[i] == '\n' {
+			idx.lineStarts = append(idx.lineStarts, i+1)
+		}
+	}
+	mc.source = idx
+	mc.headingSources = make(map[*ast.Heading]sourceHeading)
+
+	cursor := 0
+	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		if heading, ok := node.(*ast.Heading); ok {
+			if src, ok := idx.locateHeading(heading, strings.TrimSpace(mc.extractText(heading)), cursor); ok {
+				idx.headings = append(idx.headings, src)
+				mc.headingSources[heading] = src
+				cursor = src.start
+			}
+			return ast.GoToNext
+		}
+
+		leaf := node.AsLeaf()
+		if leaf == nil {
+			return ast.GoToNext
+		}
+		offset, length := idx.find(string(leaf.Literal), cursor)
+		if offset == -1 {
+			return ast.GoToNext
+		}
+
+		switch n := node.(type) {
+		case *ast.CodeBlock:
+			if line := idx.lineAt(offset) - 1; n.IsFenced && line > 0 {
+				idx.fences = append(idx.fences, sourceFence{
+					info:    strings.TrimSpace(string(n.Info)),
+					line:    line,
+					start:   idx.lineStarts[line-1],
+					content: string(n.Literal),
+				})
+			}
+		case *ast.HTMLBlock, *ast.HTMLSpan:
+			if at := bytes.Index(leaf.Literal, []byte(tfDocsBegin)); at != -1 && at < length {
+				idx.docsBegins = append(idx.docsBegins, offset+at)
+			}
+			if at := bytes.Index(leaf.Literal, []byte(tfDocsEnd)); at != -1 && at < length {
+				idx.docsEnds = append(idx.docsEnds, offset+at)
+			}
+		}
+
+		cursor = offset + length
+		return ast.GoToNext
+	})
+}
+
+func (mc *MarkdownContent) headingLine(heading *ast.Heading) int {
+	return mc.headingSources[heading].line
+}
+
+func (mc *MarkdownContent) LineOf(text string) int {
+	if text == "" {
+		return 0
+	}
+	offset := strings.Index(mc.data, text)
+	if offset == -1 {
+		return 0
+	}
+	return mc.source.lineAt(offset)
+}
+
+func (mc *MarkdownContent) linkLine(destination string) int {
+	if line := mc.LineOf("(" + destination); line > 0 {
+		return line
+	}
+	if line := mc.LineOf(strings.ReplaceAll(destination, "_", `\_`)); line > 0 {
+		return line
+	}
+	return mc.LineOf(destination)
+}
+
+func (mc *MarkdownContent) sectionLine(sectionName string) int {
+	for _, heading := range mc.sectionHeadings {
+		if mc.sectionName(heading) == sectionName {
+			return mc.headingLine(heading)
+		}
+	}
+	return 0
+}
+
+func (mc *MarkdownContent) itemLine(name string) int {
+	for _, needle := range []string{`name="input_` + name + `"`, `name="output_` + name + `"`, "[" + name + "]"} {
+		if line := mc.LineOf(needle); line > 0 {
+			return line
+		}
+	}
+	return 0
+}
+
+func (mc *MarkdownContent) locateItems(errs []error) []error {
+	for _, err := range errs {
+		var finding *Finding
+		if errors.As(err, &finding) && finding.Rule == RuleMissingInTerraform {
+			_, name, _ := strings.Cut(finding.Item, "/")
+			finding.Line = mc.itemLine(name)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,154 @@
+package markparsr
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+var htmlBreakRe = regexp.MustCompile(`(?i)^<br\s*/?>$`)
+
+type TableItem struct {
+	Name        string
+	Description string
+	Type        string
+	Default     string
+	Version     string
+	Required    bool
+}
+
+func (mc *MarkdownContent) ExtractTableItems(sectionName string) []TableItem {
+	if items := mc.tableItems(sectionName); len(items) > 0 {
+		return items
+	}
+
+	var required bool
+	switch strings.ToLower(strings.TrimSpace(sectionName)) {
+	case "required inputs":
+		required = true
+	case "optional inputs":
+	default:
+		return nil
+	}
+
+	var items []TableItem
+	for _, item := range mc.tableItems("Inputs") {
+		if item.Required == required {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (mc *MarkdownContent) extractTableSectionItems(sectionNames ...string) []string {
+	var items []string
+	seen := make(map[string]struct{})
+	for _, sectionName := range sectionNames {
+		for _, item := range mc.ExtractTableItems(sectionName) {
+			if _, ok := seen[item.Name]; ok {
+				continue
+			}
+			seen[item.Name] = struct{}{}
+			items = append(items, item.Name)
+		}
+	}
+
+	if len(items) == 0 {
+		return mc.fallbackSectionItems(sectionNames)
+	}
+	return items
+}
+
+func (mc *MarkdownContent) tableItems(sectionName string) []TableItem {
+	var items []TableItem
+	for _, heading := range mc.matchSectionHeadings(sectionName) {
+		for node := getNextSibling(heading); node != nil; node = getNextSibling(node) {
+			if h, ok := node.(*ast.Heading); ok && h.Level <= heading.Level {
+				break
+			}
+			if table, ok := node.(*ast.Table); ok {
+				items = append(items, mc.tableRows(table)...)
+			}
+		}
+	}
+	return items
+}
+
+func (mc *MarkdownContent) tableRows(table *ast.Table) []TableItem {
+	var columns []string
+	var items []TableItem
+
+	ast.WalkFunc(table, func(node ast.Node, entering bool) ast.WalkStatus {
+		row, ok := node.(*ast.TableRow)
+		if !entering || !ok {
+			return ast.GoToNext
+		}
+
+		var cells []string
+		for _, child := range row.GetChildren() {
+			if cell, ok := child.(*ast.TableCell); ok {
+				cells = append(cells, mc.tableCellText(cell))
+			}
+		}
+
+		if _, header := row.GetParent().(*ast.TableHeader); header {
+			for _, cell := range cells {
+				columns = append(columns, strings.ToLower(cell))
+			}
+			return ast.SkipChildren
+		}
+
+		var item TableItem
+		for i, cell := range cells {
+			if i >= len(columns) {
+				break
+			}
+			switch columns[i] {
+			case "name":
+				item.Name = cell
+			case "description":
+				item.Description = cell
+			case "type":
+				item.Type = strings.Trim(cell, "`")
+			case "default":
+				item.Default = strings.Trim(cell, "`")
+			case "version":
+				item.Version = cell
+			case "required":
+				item.Required = strings.EqualFold(cell, "yes")
+			}
+		}
+		if item.Name != "" {
+			items = append(items, item)
+		}
+		return ast.SkipChildren
+	})
+
+	return items
+}
+
+func (mc *MarkdownContent) tableCellText(cell *ast.TableCell) string {
+	var sb strings.Builder
+	ast.WalkFunc(cell, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		switch n := node.(type) {
+		case *ast.Text:
+			sb.Write(n.Literal)
+		case *ast.Code:
+			sb.WriteString("`")
+			sb.Write(n.Literal)
+			sb.WriteString("`")
+		case *ast.HTMLSpan:
+			if htmlBreakRe.Match(n.Literal) {
+				sb.WriteString("\n")
+			}
+		case *ast.Softbreak, *ast.Hardbreak:
+			sb.WriteString("\n")
+		}
+		return ast.GoToNext
+	})
+	return strings.TrimSpace(sb.String())
+}
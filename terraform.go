@@ -1,21 +1,22 @@
 package markparsr
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
-type defaultFileReader struct{}
-
-func (dfr *defaultFileReader) ReadFile(path string) ([]byte, error) {
-	return os.ReadFile(path)
-}
-
 type defaultHCLParser struct{}
 
 func (dhp *defaultHCLParser) ParseHCL(content []byte, filename string) (*hcl.File, hcl.Diagnostics) {
@@ -23,7 +24,40 @@ func (dhp *defaultHCLParser) ParseHCL(content []byte, filename string) (*hcl.Fil
 	return parser.ParseHCL(content, filename)
 }
 
+type ModuleBlock struct {
+	Type       string
+	Name       string
+	File       string
+	Attributes map[string]*ModuleAttribute
+}
+
+type ModuleAttribute struct {
+	Expr   hcl.Expression
+	Source string
+}
+
+func (mb *ModuleBlock) merge(override *ModuleBlock) {
+	for name, attr := range override.Attributes {
+		mb.Attributes[name] = attr
+	}
+}
+
+type ProviderRequirement struct {
+	Name    string
+	Source  string
+	Version string
+}
+
+func (pr ProviderRequirement) Namespace() string {
+	parts := strings.Split(pr.Source, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
+
 type TerraformContent struct {
+	fsys       fs.FS
 	workspace  string
 	fileReader FileReader
 	hclParser  HCLParser
@@ -43,9 +77,24 @@ func NewTerraformContent(modulePath string) (*TerraformContent, error) {
 		}
 	}
 
+	fsys, workspace, err := localFS(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve module path %s: %w", modulePath, err)
+	}
+
+	return NewTerraformContentFS(fsys, workspace)
+}
+
+func NewTerraformContentFS(fsys fs.FS, modulePath string) (*TerraformContent, error) {
+	workspace := cleanFSPath(modulePath)
+	if !fs.ValidPath(workspace) {
+		return nil, fmt.Errorf("invalid module path: %s", modulePath)
+	}
+
 	return &TerraformContent{
-		workspace:  modulePath,
-		fileReader: &defaultFileReader{},
+		fsys:       fsys,
+		workspace:  workspace,
+		fileReader: &fsFileReader{fsys: fsys},
 		hclParser:  &defaultHCLParser{},
 	}, nil
 }
@@ -53,21 +102,25 @@ func NewTerraformContent(modulePath string) (*TerraformContent, error) {
 func (tc *TerraformContent) parseFile(filePath string) (*hcl.File, error) {
 	content, err := tc.fileReader.ReadFile(filePath)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("error reading file %s: %w", filepath.Base(filePath), err)
+		return nil, fmt.Errorf("error reading file %s: %w", path.Base(filePath), err)
 	}
 
 	file, parseDiags := tc.hclParser.ParseHCL(content, filePath)
 	if parseDiags.HasErrors() {
-		return nil, fmt.Errorf("error parsing HCL in %s: %v", filepath.Base(filePath), parseDiags)
+		return nil, fmt.Errorf("error parsing HCL in %s: %v", path.Base(filePath), parseDiags)
 	}
 
 	return file, nil
 }
 
 func (tc *TerraformContent) ExtractItems(filePath, blockType string) ([]string, error) {
+	filePath, err := tc.resolvePath(filePath)
+	if err != nil {
+		return nil, err
+	}
 	file, err := tc.parseFile(filePath)
 	if err != nil {
 		return nil, err
@@ -79,6 +132,17 @@ func (tc *TerraformContent) ExtractItems(filePath, blockType string) ([]string,
 	return tc.extractItemsFromFile(file, filePath, blockType)
 }
 
+func (tc *TerraformContent) resolvePath(filePath string) (string, error) {
+	name := path.Join(tc.workspace, cleanFSPath(filePath))
+	if _, local := tc.fsys.(dirFS); local && filepath.IsAbs(filePath) {
+		name = localPath(filePath)
+	}
+	if _, err := fs.Stat(tc.fsys, name); err != nil {
+		return "", fmt.Errorf("%s not found in module %s: %w", filePath, tc.workspace, err)
+	}
+	return name, nil
+}
+
 func (tc *TerraformContent) extractItemsFromFile(file *hcl.File, filePath, blockType string) ([]string, error) {
 	var items []string
 	body := file.Body
@@ -89,7 +153,7 @@ func (tc *TerraformContent) extractItemsFromFile(file *hcl.File, filePath, block
 	})
 
 	if diags.HasErrors() {
-		return nil, fmt.Errorf("error getting content from %s: %v", filepath.Base(filePath), diags)
+		return nil, fmt.Errorf("error getting content from %s: %v", path.Base(filePath), diags)
 	}
 
 	if hclContent == nil {
@@ -107,58 +171,158 @@ func (tc *TerraformContent) extractItemsFromFile(file *hcl.File, filePath, block
 }
 
 func (tc *TerraformContent) ExtractModuleItems(blockType string) ([]string, error) {
-	files, err := os.ReadDir(tc.workspace)
+	return tc.ExtractModuleItemsContext(context.Background(), blockType)
+}
+
+func (tc *TerraformContent) ExtractModuleItemsContext(ctx context.Context, blockType string) ([]string, error) {
+	blocks, err := tc.ExtractModuleBlocksContext(ctx, blockType)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil
-		}
-		return nil, fmt.Errorf("error reading directory %s: %w", tc.workspace, err)
+		return nil, err
 	}
 
-	seen := make(map[string]struct{})
-	var items []string
+	items := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		items = append(items, block.Name)
+	}
 
-	for _, file := range files {
-		if file.IsDir() || !strings.HasSuffix(file.Name(), ".tf") {
-			continue
+	return items, nil
+}
+
+func (tc *TerraformContent) ExtractModuleBlocks(blockType string) ([]*ModuleBlock, error) {
+	return tc.ExtractModuleBlocksContext(context.Background(), blockType)
+}
+
+func (tc *TerraformContent) ExtractModuleBlocksContext(ctx context.Context, blockType string) ([]*ModuleBlock, error) {
+	primaryFiles, overrideFiles, err := tc.moduleFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]*ModuleBlock)
+	var blocks []*ModuleBlock
+
+	for _, filePath := range primaryFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
 
-		filePath := filepath.Join(tc.workspace, file.Name())
-		fileItems, err := tc.ExtractItems(filePath, blockType)
+		fileBlocks, err := tc.extractBlocks(filePath, blockType)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, item := range fileItems {
-			if _, ok := seen[item]; ok {
+		for _, block := range fileBlocks {
+			if _, ok := index[block.Name]; ok {
 				continue
 			}
-			seen[item] = struct{}{}
-			items = append(items, item)
+			index[block.Name] = block
+			blocks = append(blocks, block)
 		}
 	}
 
-	return items, nil
+	for _, filePath := range overrideFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		fileBlocks, err := tc.extractBlocks(filePath, blockType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, override := range fileBlocks {
+			base, ok := index[override.Name]
+			if !ok {
+				return nil, fmt.Errorf("%s %q in %s overrides a block that is not declared", blockType, override.Name, path.Base(filePath))
+			}
+			base.merge(override)
+		}
+	}
+
+	return blocks, nil
 }
 
-func (tc *TerraformContent) ExtractResourcesAndDataSources() ([]string, []string, error) {
-	var resources []string
-	var dataSources []string
+func (tc *TerraformContent) extractBlocks(filePath, blockType string) ([]*ModuleBlock, error) {
+	file, err := tc.parseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, nil
+	}
 
-	files, err := os.ReadDir(tc.workspace)
+	hclContent, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: blockType, LabelNames: []string{"name"}},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("error getting content from %s: %v", path.Base(filePath), diags)
+	}
+
+	var blocks []*ModuleBlock
+	for _, block := range hclContent.Blocks {
+		if len(block.Labels) == 0 {
+			continue
+		}
+
+		blocks = append(blocks, &ModuleBlock{
+			Type:       blockType,
+			Name:       strings.TrimSpace(block.Labels[0]),
+			File:       filePath,
+			Attributes: blockAttributes(block, file.Bytes),
+		})
+	}
+
+	return blocks, nil
+}
+
+func (tc *TerraformContent) moduleFiles() ([]string, []string, error) {
+	files, err := fs.ReadDir(tc.fsys, tc.workspace)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return resources, dataSources, nil
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, nil
 		}
 		return nil, nil, fmt.Errorf("error reading directory %s: %w", tc.workspace, err)
 	}
 
+	var primaryFiles []string
+	var overrideFiles []string
+
 	for _, file := range files {
 		if file.IsDir() || !strings.HasSuffix(file.Name(), ".tf") {
 			continue
 		}
 
-		filePath := filepath.Join(tc.workspace, file.Name())
+		filePath := path.Join(tc.workspace, file.Name())
+		if isOverrideFile(file.Name()) {
+			overrideFiles = append(overrideFiles, filePath)
+		} else {
+			primaryFiles = append(primaryFiles, filePath)
+		}
+	}
+
+	return primaryFiles, overrideFiles, nil
+}
+
+func (tc *TerraformContent) ExtractResourcesAndDataSources() ([]string, []string, error) {
+	return tc.ExtractResourcesAndDataSourcesContext(context.Background())
+}
+
+func (tc *TerraformContent) ExtractResourcesAndDataSourcesContext(ctx context.Context) ([]string, []string, error) {
+	var resources []string
+	var dataSources []string
+
+	primaryFiles, overrideFiles, err := tc.moduleFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, filePath := range primaryFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
 		fileResources, fileDataSources, err := tc.extractFromFilePath(filePath)
 		if err != nil {
 			return nil, nil, err
@@ -168,6 +332,24 @@ func (tc *TerraformContent) ExtractResourcesAndDataSources() ([]string, []string
 		dataSources = append(dataSources, fileDataSources...)
 	}
 
+	for _, filePath := range overrideFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		fileResources, fileDataSources, err := tc.extractFromFilePath(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if missing := firstMissing(fileResources, resources); missing != "" {
+			return nil, nil, fmt.Errorf("resource %q in %s overrides a block that is not declared", missing, path.Base(filePath))
+		}
+		if missing := firstMissing(fileDataSources, dataSources); missing != "" {
+			return nil, nil, fmt.Errorf("data source %q in %s overrides a block that is not declared", missing, path.Base(filePath))
+		}
+	}
+
 	return resources, dataSources, nil
 }
 
@@ -195,7 +377,7 @@ func (tc *TerraformContent) extractResourcesFromFile(file *hcl.File, filePath st
 	})
 
 	if diags.HasErrors() {
-		return nil, nil, fmt.Errorf("error getting content from %s: %v", filepath.Base(filePath), diags)
+		return nil, nil, fmt.Errorf("error getting content from %s: %v", path.Base(filePath), diags)
 	}
 
 	if hclContent == nil {
@@ -221,3 +403,169 @@ func (tc *TerraformContent) extractResourcesFromFile(file *hcl.File, filePath st
 
 	return resources, dataSources, nil
 }
+
+func blockAttributes(block *hcl.Block, src []byte) map[string]*ModuleAttribute {
+	attributes := make(map[string]*ModuleAttribute)
+
+	body, ok := block.Body.(*hclsyntax.Body)
+	if !ok {
+		return attributes
+	}
+
+	for name, attr := range body.Attributes {
+		attributes[name] = &ModuleAttribute{
+			Expr:   attr.Expr,
+			Source: string(attr.Expr.Range().SliceBytes(src)),
+		}
+	}
+
+	return attributes
+}
+
+func isOverrideFile(name string) bool {
+	base := strings.TrimSuffix(name, ".tf")
+	return base == "override" || strings.HasSuffix(base, "_override")
+}
+
+func firstMissing(items, declared []string) string {
+	for _, item := range items {
+		if !slices.Contains(declared, item) {
+			return item
+		}
+	}
+	return ""
+}
+
+func (tc *TerraformContent) ExtractProviderRequirements() (map[string]ProviderRequirement, error) {
+	return tc.ExtractProviderRequirementsContext(context.Background())
+}
+
+func (tc *TerraformContent) ExtractProviderRequirementsContext(ctx context.Context) (map[string]ProviderRequirement, error) {
+	primaryFiles, overrideFiles, err := tc.moduleFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := make(map[string]ProviderRequirement)
+	for _, filePath := range append(primaryFiles, overrideFiles...) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		file, err := tc.parseFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		if file == nil {
+			continue
+		}
+
+		for _, block := range nestedBlocks(file.Body, "terraform", "required_providers") {
+			attrs, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				return nil, fmt.Errorf("error getting required providers from %s: %v", path.Base(filePath), diags)
+			}
+
+			for name, attr := range attrs {
+				requirement := requirements[name]
+				requirement.Name = name
+				mergeProviderRequirement(&requirement, attr)
+				requirements[name] = requirement
+			}
+		}
+	}
+
+	return requirements, nil
+}
+
+func (tc *TerraformContent) ExtractRequiredVersion() (string, error) {
+	return tc.ExtractRequiredVersionContext(context.Background())
+}
+
+func (tc *TerraformContent) ExtractRequiredVersionContext(ctx context.Context) (string, error) {
+	primaryFiles, overrideFiles, err := tc.moduleFiles()
+	if err != nil {
+		return "", err
+	}
+
+	var version string
+	for _, filePath := range append(primaryFiles, overrideFiles...) {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		file, err := tc.parseFile(filePath)
+		if err != nil {
+			return "", err
+		}
+		if file == nil {
+			continue
+		}
+
+		for _, block := range nestedBlocks(file.Body, "terraform") {
+			attrs, _ := block.Body.JustAttributes()
+			attr, ok := attrs["required_version"]
+			if !ok {
+				continue
+			}
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+				continue
+			}
+			version = value.AsString()
+		}
+	}
+
+	return version, nil
+}
+
+func nestedBlocks(body hcl.Body, blockTypes ...string) []*hcl.Block {
+	if len(blockTypes) == 0 {
+		return nil
+	}
+
+	content, _, diags := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: blockTypes[0]}},
+	})
+	if diags.HasErrors() || content == nil {
+		return nil
+	}
+
+	if len(blockTypes) == 1 {
+		return content.Blocks
+	}
+
+	var blocks []*hcl.Block
+	for _, block := range content.Blocks {
+		blocks = append(blocks, nestedBlocks(block.Body, blockTypes[1:]...)...)
+	}
+	return blocks
+}
+
+func mergeProviderRequirement(requirement *ProviderRequirement, attr *hcl.Attribute) {
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsWhollyKnown() {
+		return
+	}
+
+	if value.Type() == cty.String {
+		requirement.Version = value.AsString()
+		return
+	}
+
+	if !value.Type().IsObjectType() && !value.Type().IsMapType() {
+		return
+	}
+
+	for key, v := range value.AsValueMap() {
+		if v.IsNull() || v.Type() != cty.String {
+			continue
+		}
+		switch key {
+		case "source":
+			requirement.Source = v.AsString()
+		case "version":
+			requirement.Version = v.AsString()
+		}
+	}
+}
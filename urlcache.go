@@ -0,0 +1,154 @@
+package markparsr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const defaultURLCacheTTL = 24 * time.Hour
+
+type URLCacheEntry struct {
+	Status    int       `json:"status"`
+	CheckedAt time.Time `json:"checked_at"`
+	Redirect  string    `json:"redirect,omitempty"`
+}
+
+type URLCache struct {
+	file *urlCacheFile
+	ttl  time.Duration
+}
+
+type urlCacheFile struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]URLCacheEntry
+	dirty   bool
+}
+
+var urlCaches = struct {
+	sync.Mutex
+	byPath map[string]*urlCacheFile
+}{byPath: make(map[string]*urlCacheFile)}
+
+func OpenURLCache(path string, ttl time.Duration) (*URLCache, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve URL cache path %s: %w", path, err)
+	}
+	if ttl <= 0 {
+		ttl = defaultURLCacheTTL
+	}
+
+	urlCaches.Lock()
+	defer urlCaches.Unlock()
+
+	if file, ok := urlCaches.byPath[absPath]; ok {
+		return &URLCache{file: file, ttl: ttl}, nil
+	}
+
+	entries, err := readURLCache(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &urlCacheFile{
+		path:    absPath,
+		entries: entries,
+	}
+	urlCaches.byPath[absPath] = file
+	return &URLCache{file: file, ttl: ttl}, nil
+}
+
+func (c *URLCache) Lookup(url string) (URLCacheEntry, bool) {
+	c.file.mu.Lock()
+	defer c.file.mu.Unlock()
+
+	entry, ok := c.file.entries[url]
+	if !ok || time.Since(entry.CheckedAt) > c.ttl {
+		return URLCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *URLCache) Store(url string, status int, redirect string) {
+	c.file.mu.Lock()
+	defer c.file.mu.Unlock()
+
+	c.file.entries[url] = URLCacheEntry{
+		Status:    status,
+		CheckedAt: time.Now().UTC(),
+		Redirect:  redirect,
+	}
+	c.file.dirty = true
+}
+
+func (c *URLCache) Save() error {
+	c.file.mu.Lock()
+	defer c.file.mu.Unlock()
+
+	if !c.file.dirty {
+		return nil
+	}
+
+	onDisk, err := readURLCache(c.file.path)
+	if err != nil {
+		return err
+	}
+	for url, entry := range onDisk {
+		if current, ok := c.file.entries[url]; !ok || entry.CheckedAt.After(current.CheckedAt) {
+			c.file.entries[url] = entry
+		}
+	}
+
+	data, err := json.MarshalIndent(c.file.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode URL cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.file.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create URL cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.file.path), filepath.Base(c.file.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write URL cache %s: %w", c.file.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write URL cache %s: %w", c.file.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write URL cache %s: %w", c.file.path, err)
+	}
+	if err := os.Rename(tmp.Name(), c.file.path); err != nil {
+		return fmt.Errorf("failed to write URL cache %s: %w", c.file.path, err)
+	}
+
+	c.file.dirty = false
+	return nil
+}
+
+func readURLCache(path string) (map[string]URLCacheEntry, error) {
+	entries := make(map[string]URLCacheEntry)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("failed to read URL cache %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse URL cache %s: %w", path, err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,106 @@
+package markparsr
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var defaultURLExcludes = []string{"*registry.terraform.io/providers/*"}
+
+type HostConfig struct {
+	Timeout    time.Duration
+	AuthHeader string
+	AuthEnv    string
+}
+
+type urlPattern struct {
+	raw string
+	re  *regexp.Regexp
+}
+
+func compileURLPatterns(patterns []string) ([]urlPattern, error) {
+	compiled := make([]urlPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := compileURLPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, urlPattern{raw: pattern, re: re})
+	}
+	return compiled, nil
+}
+
+func compileURLPattern(pattern string) (*regexp.Regexp, error) {
+	if expr, ok := strings.CutPrefix(pattern, "re:"); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL pattern %q: %w", pattern, err)
+		}
+		return re, nil
+	}
+	return globToRegexp(pattern), nil
+}
+
+func globToRegexp(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("(?i)^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+func matchesAnyPattern(patterns []urlPattern, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchHost(pattern, host string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	host = strings.ToLower(host)
+	if pattern == host {
+		return true
+	}
+	if strings.ContainsAny(pattern, "*?") {
+		return globToRegexp(pattern).MatchString(host)
+	}
+	return strings.HasSuffix(host, "."+pattern)
+}
+
+func urlHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+func (hc HostConfig) authValue() string {
+	if hc.AuthEnv == "" {
+		return ""
+	}
+	return os.Getenv(hc.AuthEnv)
+}
+
+func (hc HostConfig) authHeader() string {
+	if hc.AuthHeader == "" {
+		return "Authorization"
+	}
+	return hc.AuthHeader
+}
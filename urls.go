@@ -1,69 +1,569 @@
 package markparsr
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"html"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gomarkdown/markdown/ast"
 	"mvdan.cc/xurls/v2"
 )
 
+const (
+	defaultURLTimeout      = 10 * time.Second
+	defaultRetryBackoff    = 500 * time.Millisecond
+	defaultHostConcurrency = 2
+	urlWorkers             = 8
+	maxRetryDelay          = 30 * time.Second
+)
+
+var (
+	htmlCommentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlURLAttrRe = regexp.MustCompile(`(?i)\s(?:href|src)\s*=\s*["']([^"']+)["']`)
+)
+
+type StatusRange struct {
+	Min int
+	Max int
+}
+
+func (sr StatusRange) Contains(status int) bool {
+	return status >= sr.Min && status <= sr.Max
+}
+
+type URLConfig struct {
+	Client          *http.Client
+	Offline         bool
+	Cache           *URLCache
+	Retries         int
+	RetryBackoff    time.Duration
+	AcceptedStatus  []StatusRange
+	HostConcurrency int
+	HostInterval    time.Duration
+	Include         []string
+	Exclude         []string
+	ForbiddenHosts  []string
+	Hosts           map[string]HostConfig
+	IncludeCode     bool
+}
+
 type URLValidator struct {
-	content *MarkdownContent
+	content        *MarkdownContent
+	client         *http.Client
+	offline        bool
+	cache          *URLCache
+	retries        int
+	retryBackoff   time.Duration
+	acceptedStatus []StatusRange
+	limiter        *hostLimiter
+	include        []urlPattern
+	exclude        []urlPattern
+	forbiddenHosts []string
+	hosts          map[string]HostConfig
+	includeCode    bool
+	configErr      error
+	mu             sync.Mutex
+	skipped        []string
 }
 
-func NewURLValidator(content *MarkdownContent) *URLValidator {
-	return &URLValidator{content: content}
+func NewURLValidator(content *MarkdownContent, config URLConfig) *URLValidator {
+	client := config.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	retryBackoff := config.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+
+	acceptedStatus := config.AcceptedStatus
+	if len(acceptedStatus) == 0 {
+		acceptedStatus = []StatusRange{{Min: 200, Max: 299}}
+	}
+
+	hostConcurrency := config.HostConcurrency
+	if hostConcurrency <= 0 {
+		hostConcurrency = defaultHostConcurrency
+	}
+
+	excludePatterns := config.Exclude
+	if excludePatterns == nil {
+		excludePatterns = defaultURLExcludes
+	}
+
+	hosts := make(map[string]HostConfig, len(config.Hosts))
+	for host, hostConfig := range config.Hosts {
+		hosts[strings.ToLower(strings.TrimSpace(host))] = hostConfig
+	}
+
+	include, includeErr := compileURLPatterns(config.Include)
+	exclude, excludeErr := compileURLPatterns(excludePatterns)
+
+	uv := &URLValidator{
+		content:        content,
+		offline:        config.Offline,
+		cache:          config.Cache,
+		retries:        max(config.Retries, 0),
+		retryBackoff:   retryBackoff,
+		acceptedStatus: acceptedStatus,
+		limiter:        sharedHostLimiter(hostConcurrency, config.HostInterval),
+		include:        include,
+		exclude:        exclude,
+		forbiddenHosts: config.ForbiddenHosts,
+		hosts:          hosts,
+		includeCode:    config.IncludeCode,
+		configErr:      errors.Join(includeErr, excludeErr),
+	}
+	uv.client = uv.redirectClient(client)
+	return uv
+}
+
+func (uv *URLValidator) redirectClient(client *http.Client) *http.Client {
+	wrapped := *client
+	wrapped.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if original := via[0].URL; !strings.EqualFold(req.URL.Host, original.Host) {
+			if config := uv.hostConfig(urlHost(original.String())); config.authValue() != "" {
+				req.Header.Del(config.authHeader())
+			}
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &wrapped
 }
 
 func (uv *URLValidator) Validate() []error {
-	rxStrict := xurls.Strict()
-	urls := rxStrict.FindAllString(uv.content.data, -1)
+	return uv.ValidateContext(context.Background())
+}
+
+func (uv *URLValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	if uv.configErr != nil {
+		return []error{uv.configErr}
+	}
+
+	urls := uv.content.ExtractURLs(uv.includeCode)
+
+	uv.mu.Lock()
+	uv.skipped = nil
+	uv.mu.Unlock()
 
-	const maxConcurrency = 5
-	sem := make(chan struct{}, maxConcurrency)
-	var wg sync.WaitGroup
 	errChan := make(chan error, len(urls))
+	pending := make(chan string, len(urls))
 
 	for _, u := range urls {
-		if strings.Contains(u, "registry.terraform.io/providers/") {
+		if host, forbidden := uv.forbiddenHost(u); forbidden {
+			errChan <- newFinding(RuleForbiddenURL, u, "URL links to forbidden host %s: %s", host, u).at(uv.content.LineOf(u))
 			continue
 		}
+		if !uv.shouldCheck(u) {
+			continue
+		}
+		if uv.offline && !isLocalURL(u) {
+			uv.recordSkipped(u)
+			continue
+		}
+		pending <- u
+	}
+	close(pending)
+
+	var wg sync.WaitGroup
+	for range urlWorkers {
 		wg.Add(1)
-		go func(url string) {
+		go func() {
 			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-			if err := validateSingleURL(url); err != nil {
-				errChan <- err
+			for url := range pending {
+				if err := uv.validateSingleURL(ctx, url); err != nil && ctx.Err() == nil {
+					errChan <- err
+				}
 			}
-		}(u)
+		}()
 	}
 
 	wg.Wait()
 	close(errChan)
 
-	var errors []error
+	var errs []error
 	for err := range errChan {
-		errors = append(errors, err)
+		errs = append(errs, err)
+	}
+	SortFindings(errs)
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if uv.cache != nil {
+		if err := uv.cache.Save(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return errors
+	return errs
 }
 
-func validateSingleURL(url string) error {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+func (mc *MarkdownContent) ExtractURLs(includeCode bool) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(candidate string) {
+		candidate = strings.TrimSpace(candidate)
+		if seen[candidate] || !isHTTPURL(candidate) {
+			return
+		}
+		seen[candidate] = true
+		urls = append(urls, candidate)
 	}
-	resp, err := client.Get(url)
+
+	rxStrict := xurls.Strict()
+	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		switch n := node.(type) {
+		case *ast.Link:
+			add(string(n.Destination))
+		case *ast.Image:
+			add(string(n.Destination))
+		case *ast.HTMLSpan:
+			for _, u := range htmlURLs(n.Literal) {
+				add(u)
+			}
+		case *ast.HTMLBlock:
+			for _, u := range htmlURLs(n.Literal) {
+				add(u)
+			}
+		case *ast.CodeBlock, *ast.Code:
+			if includeCode {
+				for _, u := range rxStrict.FindAllString(string(n.AsLeaf().Literal), -1) {
+					add(u)
+				}
+			}
+		}
+		return ast.GoToNext
+	})
+
+	return urls
+}
+
+func htmlURLs(literal []byte) []string {
+	withoutComments := htmlCommentRe.ReplaceAll(literal, nil)
+
+	var urls []string
+	for _, match := range htmlURLAttrRe.FindAllSubmatch(withoutComments, -1) {
+		urls = append(urls, html.UnescapeString(string(match[1])))
+	}
+	return urls
+}
+
+func isHTTPURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
 	if err != nil {
-		return fmt.Errorf("error accessing URL: %s: %w", url, err)
+		return false
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("URL returned non-OK status: %s: Status: %d", url, resp.StatusCode)
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func (uv *URLValidator) shouldCheck(rawURL string) bool {
+	if len(uv.include) > 0 && !matchesAnyPattern(uv.include, rawURL) {
+		return false
+	}
+	return !matchesAnyPattern(uv.exclude, rawURL)
+}
+
+func (uv *URLValidator) forbiddenHost(rawURL string) (string, bool) {
+	host := urlHost(rawURL)
+	if host == "" {
+		return "", false
+	}
+	for _, pattern := range uv.forbiddenHosts {
+		if matchHost(pattern, host) {
+			return host, true
+		}
+	}
+	return "", false
+}
+
+func (uv *URLValidator) hostConfig(host string) HostConfig {
+	if config, ok := uv.hosts[host]; ok {
+		return config
+	}
+
+	var best string
+	for pattern := range uv.hosts {
+		if matchHost(pattern, host) && len(pattern) > len(best) {
+			best = pattern
+		}
+	}
+	return uv.hosts[best]
+}
+
+func (uv *URLValidator) SkippedURLs() []string {
+	uv.mu.Lock()
+	defer uv.mu.Unlock()
+	return append([]string(nil), uv.skipped...)
+}
+
+func (uv *URLValidator) recordSkipped(url string) {
+	uv.mu.Lock()
+	defer uv.mu.Unlock()
+	uv.skipped = append(uv.skipped, url)
+	if os.Getenv("VERBOSE") == "true" {
+		fmt.Printf("Skipping external URL in offline mode: %s\n", url)
+	}
+}
+
+func (uv *URLValidator) validateSingleURL(ctx context.Context, url string) error {
+	if uv.cache != nil {
+		if entry, ok := uv.cache.Lookup(url); ok && uv.acceptStatus(entry.Status) {
+			return nil
+		}
+	}
+
+	status, redirect, err := uv.check(ctx, url)
+	if err != nil {
+		return newFinding(RuleBrokenURL, url, "error accessing URL: %s: %w", url, err).at(uv.content.LineOf(url))
+	}
+
+	if uv.cache != nil {
+		uv.cache.Store(url, status, redirect)
+	}
+
+	if !uv.acceptStatus(status) {
+		return newFinding(RuleBrokenURL, url, "URL returned non-OK status: %s: Status: %d", url, status).at(uv.content.LineOf(url))
 	}
 	return nil
 }
+
+func (uv *URLValidator) check(ctx context.Context, url string) (int, string, error) {
+	status, redirect, retried, err := uv.requestWithRetries(ctx, http.MethodHead, url, uv.retries)
+	if (err == nil && uv.acceptStatus(status)) || ctx.Err() != nil {
+		return status, redirect, err
+	}
+	status, redirect, _, err = uv.requestWithRetries(ctx, http.MethodGet, url, uv.retries-retried)
+	return status, redirect, err
+}
+
+func (uv *URLValidator) requestWithRetries(ctx context.Context, method, url string, retries int) (int, string, int, error) {
+	var status int
+	var redirect string
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		var retryAfter time.Duration
+		status, redirect, retryAfter, err = uv.request(ctx, method, url)
+		if attempt >= retries || !shouldRetry(status, err) || ctx.Err() != nil {
+			return status, redirect, attempt, err
+		}
+
+		delay := max(uv.retryBackoff<<attempt, retryAfter)
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return status, redirect, attempt, err
+		}
+	}
+}
+
+func (uv *URLValidator) request(ctx context.Context, method, rawURL string) (int, string, time.Duration, error) {
+	hostConfig := uv.hostConfig(urlHost(rawURL))
+	timeout := hostConfig.Timeout
+	if timeout <= 0 {
+		timeout = defaultURLTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	if value := hostConfig.authValue(); value != "" {
+		req.Header.Set(hostConfig.authHeader(), value)
+	}
+
+	release, err := uv.limiter.acquire(ctx, req.URL.Host)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	defer release()
+
+	resp, err := uv.client.Do(req)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, redirectTarget(rawURL, resp), parseRetryAfter(resp.Header.Get("Retry-After")), nil
+}
+
+func (uv *URLValidator) acceptStatus(status int) bool {
+	for _, r := range uv.acceptedStatus {
+		if r.Contains(status) {
+			return true
+		}
+	}
+	return false
+}
+
+func shouldRetry(status int, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return max(time.Until(when), 0)
+	}
+	return 0
+}
+
+func redirectTarget(url string, resp *http.Response) string {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return ""
+	}
+	if target := resp.Request.URL.String(); target != url {
+		return target
+	}
+	return ""
+}
+
+func isLocalURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	host := parsed.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+type hostLimiter struct {
+	concurrency int
+	interval    time.Duration
+	mu          sync.Mutex
+	hosts       map[string]*hostSlot
+}
+
+type hostLimiterKey struct {
+	concurrency int
+	interval    time.Duration
+}
+
+var hostLimiters = struct {
+	sync.Mutex
+	byConfig map[hostLimiterKey]*hostLimiter
+}{byConfig: make(map[hostLimiterKey]*hostLimiter)}
+
+type hostSlot struct {
+	sem  chan struct{}
+	mu   sync.Mutex
+	next time.Time
+}
+
+func sharedHostLimiter(concurrency int, interval time.Duration) *hostLimiter {
+	hostLimiters.Lock()
+	defer hostLimiters.Unlock()
+
+	key := hostLimiterKey{concurrency: concurrency, interval: interval}
+	if limiter, ok := hostLimiters.byConfig[key]; ok {
+		return limiter
+	}
+
+	limiter := &hostLimiter{
+		concurrency: concurrency,
+		interval:    interval,
+		hosts:       make(map[string]*hostSlot),
+	}
+	hostLimiters.byConfig[key] = limiter
+	return limiter
+}
+
+func (hl *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	host = strings.ToLower(host)
+
+	hl.mu.Lock()
+	slot, ok := hl.hosts[host]
+	if !ok {
+		slot = &hostSlot{sem: make(chan struct{}, hl.concurrency)}
+		hl.hosts[host] = slot
+	}
+	hl.mu.Unlock()
+
+	select {
+	case slot.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	release := func() { <-slot.sem }
+
+	if hl.interval > 0 {
+		slot.mu.Lock()
+		wait := time.Until(slot.next)
+		slot.next = time.Now().Add(max(wait, 0) + hl.interval)
+		slot.mu.Unlock()
+		if err := sleepContext(ctx, wait); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
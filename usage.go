@@ -0,0 +1,199 @@
+package markparsr
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var moduleMetaArguments = []string{"source", "version", "providers", "depends_on", "count", "for_each"}
+
+type UsageValidator struct {
+	content   *MarkdownContent
+	terraform *TerraformContent
+}
+
+func NewUsageValidator(content *MarkdownContent, terraform *TerraformContent) *UsageValidator {
+	return &UsageValidator{content: content, terraform: terraform}
+}
+
+func (uv *UsageValidator) Validate() []error {
+	return uv.ValidateContext(context.Background())
+}
+
+func (uv *UsageValidator) ValidateContext(ctx context.Context) []error {
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	fences := uv.content.usageFences()
+	if len(fences) == 0 {
+		return nil
+	}
+
+	variables, err := uv.terraform.ExtractModuleBlocksContext(ctx, "variable")
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	var calls []*hclsyntax.Block
+	for _, fence := range fences {
+		file, diags := hclsyntax.ParseConfig([]byte(fence.content), "README.md", hcl.Pos{Line: fence.line + 1, Column: 1})
+		if diags.HasErrors() {
+			for _, diag := range diags {
+				if diag.Severity != hcl.DiagError {
+					continue
+				}
+				line := fence.line
+				if diag.Subject != nil {
+					line = diag.Subject.Start.Line
+				}
+				errs = append(errs, newFinding(RuleUsage, "Usage", "usage example has invalid HCL: %s", diag.Summary).at(line))
+				break
+			}
+			continue
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type == "module" && len(block.Labels) > 0 {
+				calls = append(calls, block)
+			}
+		}
+	}
+
+	for _, call := range uv.moduleCalls(calls, variables) {
+		errs = append(errs, uv.checkModuleCall(call, variables)...)
+	}
+	return errs
+}
+
+func (uv *UsageValidator) moduleCalls(calls []*hclsyntax.Block, variables []*ModuleBlock) []*hclsyntax.Block {
+	name := path.Base(uv.terraform.workspace)
+	own := slices.DeleteFunc(slices.Clone(calls), func(call *hclsyntax.Block) bool {
+		return !sourceRefersTo(moduleSource(call), name)
+	})
+	if len(own) > 0 || len(calls) == 0 {
+		return own
+	}
+
+	primary, best := calls[0], -1
+	for _, call := range calls {
+		declared := 0
+		for argument := range call.Body.Attributes {
+			if slices.ContainsFunc(variables, func(variable *ModuleBlock) bool { return variable.Name == argument }) {
+				declared++
+			}
+		}
+		if declared > best {
+			primary, best = call, declared
+		}
+	}
+	return []*hclsyntax.Block{primary}
+}
+
+func moduleSource(call *hclsyntax.Block) string {
+	attr, ok := call.Body.Attributes["source"]
+	if !ok {
+		return ""
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return ""
+	}
+	return value.AsString()
+}
+
+func sourceRefersTo(source, module string) bool {
+	if source == "" || module == "" || module == "." {
+		return false
+	}
+
+	source = strings.TrimPrefix(source, "git::")
+	source, _, _ = strings.Cut(source, "?")
+	if scheme := strings.Index(source, "://"); scheme != -1 {
+		source = source[scheme+3:]
+	}
+	if base, _, ok := strings.Cut(source, "//"); ok {
+		source = base
+	}
+	source = strings.TrimSuffix(strings.TrimSuffix(source, "/"), ".git")
+
+	if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") || source == "." || source == ".." {
+		return path.Base(path.Clean(source)) == module
+	}
+
+	parts := strings.Split(source, "/")
+	if len(parts) == 3 && !strings.Contains(parts[0], ".") {
+		return module == parts[1] || module == "terraform-"+parts[2]+"-"+parts[1]
+	}
+	return parts[len(parts)-1] == module
+}
+
+func (uv *UsageValidator) checkModuleCall(block *hclsyntax.Block, variables []*ModuleBlock) []error {
+	module := "module." + block.Labels[0]
+
+	var names []string
+	for name := range block.Body.Attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var errs []error
+	for _, name := range names {
+		if slices.Contains(moduleMetaArguments, name) || slices.ContainsFunc(variables, func(variable *ModuleBlock) bool {
+			return variable.Name == name
+		}) {
+			continue
+		}
+		errs = append(errs, newFinding(RuleUsage, module+"/"+name,
+			"usage example passes '%s' to %s but the module declares no such variable", name, module).
+			at(block.Body.Attributes[name].SrcRange.Start.Line))
+	}
+
+	for _, variable := range variables {
+		if _, hasDefault := variable.Attributes["default"]; hasDefault {
+			continue
+		}
+		if _, ok := block.Body.Attributes[variable.Name]; !ok {
+			errs = append(errs, newFinding(RuleUsage, module+"/"+variable.Name,
+				"usage example does not set required variable '%s' in %s", variable.Name, module).
+				at(block.TypeRange.Start.Line))
+		}
+	}
+	return errs
+}
+
+func (mc *MarkdownContent) usageFences() []sourceFence {
+	var fences []sourceFence
+	for i, heading := range mc.source.headings {
+		if !matchesSectionName(heading.text, "Usage") {
+			continue
+		}
+
+		end := len(mc.data)
+		for _, next := range mc.source.headings[i+1:] {
+			if next.level <= heading.level {
+				end = next.start
+				break
+			}
+		}
+
+		for _, fence := range mc.source.fences {
+			lang, _, _ := strings.Cut(strings.ToLower(fence.info), " ")
+			if fence.start > heading.start && fence.start < end && slices.Contains([]string{"hcl", "terraform", "tf"}, lang) {
+				fences = append(fences, fence)
+			}
+		}
+	}
+	return fences
+}
@@ -1,10 +1,19 @@
 package markparsr
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Options struct {
@@ -13,6 +22,32 @@ type Options struct {
 	AdditionalFiles    []string
 	ReadmePath         string
 	ProviderPrefixes   []string
+	FS                 fs.FS
+	BaselinePath       string
+	UpdateBaseline     bool
+	HTTPClient         *http.Client
+	OfflineURLs        bool
+	URLCachePath       string
+	URLCacheTTL        time.Duration
+	URLRetries         int
+	URLRetryBackoff    time.Duration
+	AcceptedStatus     []StatusRange
+	HostConcurrency    int
+	HostInterval       time.Duration
+	URLInclude         []string
+	URLExclude         []string
+	ForbiddenHosts     []string
+	HostConfigs        map[string]HostConfig
+	URLsInCode         bool
+	FailFast           bool
+	Concurrency        int
+	UpdateDocs         bool
+	Fix                bool
+	DryRun             bool
+	SectionSchema      []SectionSchema
+	SectionOrder       []string
+	SectionLevel       int
+	ItemLevel          int
 }
 
 type Option func(*Options)
@@ -47,26 +82,172 @@ func WithProviderPrefixes(prefixes ...string) Option {
 	}
 }
 
+func WithFS(fsys fs.FS) Option {
+	return func(o *Options) {
+		o.FS = fsys
+	}
+}
+
+func WithBaseline(path string) Option {
+	return func(o *Options) {
+		o.BaselinePath = path
+	}
+}
+
+func WithUpdateBaseline() Option {
+	return func(o *Options) {
+		o.UpdateBaseline = true
+	}
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.HTTPClient = client
+	}
+}
+
+func WithHTTPTransport(transport http.RoundTripper) Option {
+	return func(o *Options) {
+		o.HTTPClient = &http.Client{Transport: transport}
+	}
+}
+
+func WithOfflineURLs() Option {
+	return func(o *Options) {
+		o.OfflineURLs = true
+	}
+}
+
+func WithURLCache(path string, ttl time.Duration) Option {
+	return func(o *Options) {
+		o.URLCachePath = path
+		o.URLCacheTTL = ttl
+	}
+}
+
+func WithURLRetries(retries int, backoff time.Duration) Option {
+	return func(o *Options) {
+		o.URLRetries = retries
+		o.URLRetryBackoff = backoff
+	}
+}
+
+func WithAcceptedStatus(ranges ...StatusRange) Option {
+	return func(o *Options) {
+		o.AcceptedStatus = ranges
+	}
+}
+
+func WithHostRateLimit(concurrency int, interval time.Duration) Option {
+	return func(o *Options) {
+		o.HostConcurrency = concurrency
+		o.HostInterval = interval
+	}
+}
+
+func WithURLInclude(patterns ...string) Option {
+	return func(o *Options) {
+		o.URLInclude = patterns
+	}
+}
+
+func WithURLExclude(patterns ...string) Option {
+	return func(o *Options) {
+		o.URLExclude = patterns
+	}
+}
+
+func WithForbiddenHosts(hosts ...string) Option {
+	return func(o *Options) {
+		o.ForbiddenHosts = hosts
+	}
+}
+
+func WithHostConfig(host string, config HostConfig) Option {
+	return func(o *Options) {
+		if o.HostConfigs == nil {
+			o.HostConfigs = make(map[string]HostConfig)
+		}
+		o.HostConfigs[strings.ToLower(strings.TrimSpace(host))] = config
+	}
+}
+
+func WithURLsInCode() Option {
+	return func(o *Options) {
+		o.URLsInCode = true
+	}
+}
+
+func WithFailFast() Option {
+	return func(o *Options) {
+		o.FailFast = true
+	}
+}
+
+func WithConcurrency(n int) Option {
+	return func(o *Options) {
+		o.Concurrency = n
+	}
+}
+
+func WithUpdateDocs() Option {
+	return func(o *Options) {
+		o.UpdateDocs = true
+	}
+}
+
+func WithFix() Option {
+	return func(o *Options) {
+		o.Fix = true
+	}
+}
+
+func WithDryRun() Option {
+	return func(o *Options) {
+		o.DryRun = true
+	}
+}
+
+func WithSectionSchema(schema ...SectionSchema) Option {
+	return func(o *Options) {
+		o.SectionSchema = schema
+	}
+}
+
+func WithHeadingLevels(section, item int) Option {
+	return func(o *Options) {
+		o.SectionLevel = section
+		o.ItemLevel = item
+	}
+}
+
+func WithSectionOrder(sections ...string) Option {
+	return func(o *Options) {
+		o.SectionOrder = sections
+	}
+}
+
 type ReadmeValidator struct {
+	fsys       fs.FS
 	readmePath string
 	modulePath string
+	moduleName string
 	markdown   *MarkdownContent
 	terraform  *TerraformContent
 	validators []Validator
+	urlCache   *URLCache
 	options    Options
+	skipped    []string
 }
 
 func NewReadmeValidator(opts ...Option) (*ReadmeValidator, error) {
 	options := Options{
-		Format:             FormatDocument,
+		Format:             FormatAuto,
 		AdditionalSections: []string{},
 		AdditionalFiles:    []string{},
 		ReadmePath:         "",
 		ProviderPrefixes:   []string{},
-	}
-
-	for _, opt := range opts {
-		opt(&options)
+		URLRetries:         2,
 	}
 
 	if envFormat := os.Getenv("FORMAT"); envFormat != "" {
@@ -74,13 +255,59 @@ func NewReadmeValidator(opts ...Option) (*ReadmeValidator, error) {
 		case "document":
 			options.Format = FormatDocument
 		case "table":
-			fmt.Println("Table format is no longer supported; defaulting to document format")
-			options.Format = FormatDocument
+			options.Format = FormatTable
+		case "auto":
+			options.Format = FormatAuto
 		default:
-			fmt.Printf("Unknown format in FORMAT environment variable: %s, using document format\n", envFormat)
+			fmt.Printf("Unknown format in FORMAT environment variable: %s, detecting the format automatically\n", envFormat)
 		}
 	}
 
+	if envBaseline := os.Getenv("BASELINE_PATH"); envBaseline != "" {
+		options.BaselinePath = envBaseline
+	}
+	if os.Getenv("UPDATE_BASELINE") == "true" {
+		options.UpdateBaseline = true
+	}
+	if os.Getenv("OFFLINE") == "true" {
+		options.OfflineURLs = true
+	}
+	if envConcurrency := os.Getenv("CONCURRENCY"); envConcurrency != "" {
+		n, err := strconv.Atoi(envConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CONCURRENCY %q: %w", envConcurrency, err)
+		}
+		options.Concurrency = n
+	}
+	if envLevel := os.Getenv("SECTION_LEVEL"); envLevel != "" {
+		n, err := strconv.Atoi(envLevel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SECTION_LEVEL %q: %w", envLevel, err)
+		}
+		options.SectionLevel = n
+	}
+	if envLevel := os.Getenv("ITEM_LEVEL"); envLevel != "" {
+		n, err := strconv.Atoi(envLevel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ITEM_LEVEL %q: %w", envLevel, err)
+		}
+		options.ItemLevel = n
+	}
+	if envCache := os.Getenv("URL_CACHE_PATH"); envCache != "" {
+		options.URLCachePath = envCache
+	}
+	if envTTL := os.Getenv("URL_CACHE_TTL"); envTTL != "" {
+		ttl, err := time.ParseDuration(envTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL_CACHE_TTL %q: %w", envTTL, err)
+		}
+		options.URLCacheTTL = ttl
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	var finalReadmePath string
 	if options.ReadmePath != "" {
 		finalReadmePath = options.ReadmePath
@@ -94,65 +321,294 @@ func NewReadmeValidator(opts ...Option) (*ReadmeValidator, error) {
 		}
 	}
 
-	readmeFile, err := filepath.Abs(finalReadmePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get absolute path for README: %w", err)
-	}
-
-	modulePath := filepath.Dir(readmeFile)
-	if envModulePath := os.Getenv("MODULE_PATH"); envModulePath != "" {
-		modulePath = envModulePath
-	}
-
-	absModulePath, err := filepath.Abs(modulePath)
+	paths, err := resolvePaths(options.FS, finalReadmePath, os.Getenv("MODULE_PATH"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get absolute module path: %w", err)
+		return nil, err
 	}
+	fsys, readmeFile, modulePath := paths.fsys, paths.readmeFile, paths.modulePath
 
-	data, err := os.ReadFile(readmeFile)
+	data, err := fs.ReadFile(fsys, readmeFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	markdown := NewMarkdownContent(string(data), options.Format, options.ProviderPrefixes)
-
-	terraform, err := NewTerraformContent(absModulePath)
+	terraform, err := NewTerraformContentFS(fsys, modulePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize terraform content: %w", err)
 	}
 
+	readme := string(data)
+	markdown := NewMarkdownContentWithLevels(readme, options.Format, options.ProviderPrefixes, options.SectionLevel, options.ItemLevel)
+
 	validator := &ReadmeValidator{
+		fsys:       fsys,
 		readmePath: readmeFile,
-		modulePath: absModulePath,
+		modulePath: modulePath,
+		moduleName: paths.moduleName,
 		markdown:   markdown,
 		terraform:  terraform,
 		options:    options,
 	}
 
-	validator.validators = buildDefaultValidators(readmeFile, absModulePath, markdown, terraform, options)
+	if options.URLCachePath != "" {
+		validator.urlCache, err = OpenURLCache(options.URLCachePath, options.URLCacheTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	validator.validators = buildDefaultValidators(fsys, readmeFile, modulePath, markdown, terraform, validator.urlCache, options)
 
 	return validator, nil
 }
 
-func buildDefaultValidators(readmePath, modulePath string, markdown *MarkdownContent, terraform *TerraformContent, options Options) []Validator {
+type resolvedPaths struct {
+	fsys       fs.FS
+	readmeFile string
+	modulePath string
+	moduleName string
+}
+
+func resolvePaths(fsys fs.FS, readmePath, modulePath string) (resolvedPaths, error) {
+	if fsys != nil {
+		readmeFile := cleanFSPath(readmePath)
+		if !fs.ValidPath(readmeFile) {
+			return resolvedPaths{}, fmt.Errorf("invalid README path: %s", readmePath)
+		}
+
+		moduleDir := path.Dir(readmeFile)
+		if modulePath != "" {
+			moduleDir = cleanFSPath(modulePath)
+		}
+
+		return resolvedPaths{fsys: fsys, readmeFile: readmeFile, modulePath: moduleDir, moduleName: moduleDir}, nil
+	}
+
+	absReadmePath, err := filepath.Abs(readmePath)
+	if err != nil {
+		return resolvedPaths{}, fmt.Errorf("failed to get absolute path for README: %w", err)
+	}
+
+	if modulePath == "" {
+		modulePath = filepath.Dir(absReadmePath)
+	}
+
+	absModulePath, err := filepath.Abs(modulePath)
+	if err != nil {
+		return resolvedPaths{}, fmt.Errorf("failed to get absolute module path: %w", err)
+	}
+
+	return resolvedPaths{
+		fsys:       localRoot(absModulePath),
+		readmeFile: localPath(absReadmePath),
+		modulePath: localPath(absModulePath),
+		moduleName: absModulePath,
+	}, nil
+}
+
+func buildDefaultValidators(fsys fs.FS, readmePath, modulePath string, markdown *MarkdownContent, terraform *TerraformContent, urlCache *URLCache, options Options) []Validator {
+	schema := options.SectionSchema
+	if schema == nil {
+		schema = DefaultSectionSchema(markdown.format)
+	}
+
 	return []Validator{
-		NewSectionValidator(markdown, options.AdditionalSections),
-		NewFileValidator(readmePath, modulePath, options.AdditionalFiles),
-		NewURLValidator(markdown),
+		NewSectionValidatorWithSchema(markdown, terraform, schema, options.AdditionalSections),
+		NewDocsBlockValidator(markdown),
+		NewSectionOrderValidator(markdown, options.SectionOrder),
+		NewDuplicateValidator(markdown),
+		NewFileValidatorFS(fsys, readmePath, modulePath, options.AdditionalFiles),
+		NewURLValidator(markdown, URLConfig{
+			Client:          options.HTTPClient,
+			Offline:         options.OfflineURLs,
+			Cache:           urlCache,
+			Retries:         options.URLRetries,
+			RetryBackoff:    options.URLRetryBackoff,
+			AcceptedStatus:  options.AcceptedStatus,
+			HostConcurrency: options.HostConcurrency,
+			HostInterval:    options.HostInterval,
+			Include:         options.URLInclude,
+			Exclude:         options.URLExclude,
+			ForbiddenHosts:  options.ForbiddenHosts,
+			Hosts:           options.HostConfigs,
+			IncludeCode:     options.URLsInCode,
+		}),
+		NewAnchorValidator(markdown),
+		NewRelativeLinkValidator(fsys, readmePath, modulePath, markdown),
 		NewTerraformDefinitionValidator(markdown, terraform),
+		NewRegistryLinkValidator(markdown, terraform),
 		NewItemValidator(markdown, terraform, "Variables", "variable", []string{"Required Inputs", "Optional Inputs"}, "variables.tf"),
 		NewItemValidator(markdown, terraform, "Outputs", "output", []string{"Outputs"}, "outputs.tf"),
+		NewUsageValidator(markdown, terraform),
 	}
 }
 
 func (rv *ReadmeValidator) Validate() []error {
-	collector := &ErrorCollector{}
+	return rv.ValidateContext(context.Background())
+}
+
+func (rv *ReadmeValidator) ValidateContext(ctx context.Context) []error {
+	if rv.options.UpdateDocs {
+		if err := rv.UpdateDocsContext(ctx); err != nil {
+			return []error{err}
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := rv.options.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
 
+	results := make([][]error, len(rv.validators))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, validator := range rv.validators {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-runCtx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			if runCtx.Err() != nil {
+				return
+			}
+
+			results[i] = validator.ValidateContext(runCtx)
+			if rv.options.FailFast && len(results[i]) > 0 {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	rv.skipped = nil
 	for _, validator := range rv.validators {
-		collector.AddMany(validator.Validate())
+		if uv, ok := validator.(*URLValidator); ok {
+			rv.skipped = append(rv.skipped, uv.SkippedURLs()...)
+		}
+	}
+
+	collector := &ErrorCollector{}
+	for _, errs := range results {
+		for _, err := range errs {
+			var finding *Finding
+			if !errors.As(err, &finding) && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+				continue
+			}
+			collector.Add(rv.locate(err))
+		}
+	}
+
+	errs := collector.Errors()
+	SortFindings(errs)
+
+	if err := ctx.Err(); err != nil {
+		return append(errs, err)
+	}
+	if rv.options.Fix {
+		var err error
+		if errs, err = rv.applyFixes(errs); err != nil {
+			return append(errs, err)
+		}
+	}
+	if rv.options.BaselinePath == "" {
+		if rv.options.UpdateBaseline {
+			return append(errs, fmt.Errorf("updating the baseline requires a baseline path: set BASELINE_PATH or use WithBaseline"))
+		}
+		return errs
 	}
 
-	return collector.Errors()
+	errs, err := rv.applyBaseline(errs)
+	if err != nil {
+		return append(errs, err)
+	}
+	return errs
+}
+
+func (rv *ReadmeValidator) locate(err error) error {
+	var finding *Finding
+	if errors.As(err, &finding) && finding.File == "" && finding.Line > 0 {
+		finding.File = rv.readmePath
+	}
+	return err
+}
+
+func (rv *ReadmeValidator) applyFixes(errs []error) ([]error, error) {
+	content := rv.markdown.GetContent()
+	updated, remaining := ApplyFixes(content, errs)
+	if updated == content {
+		return errs, nil
+	}
+
+	if err := writeReadme(rv.fsys, rv.readmePath, content, updated, rv.options.DryRun); err != nil {
+		return errs, err
+	}
+	if rv.options.DryRun {
+		return errs, nil
+	}
+	if os.Getenv("VERBOSE") == "true" {
+		fmt.Printf("Applied %d fixes to %s\n", len(errs)-len(remaining), rv.readmePath)
+	}
+
+	rv.reload(updated)
+	return remaining, nil
+}
+
+func (rv *ReadmeValidator) UpdateDocs() error {
+	return rv.UpdateDocsContext(context.Background())
+}
+
+func (rv *ReadmeValidator) UpdateDocsContext(ctx context.Context) error {
+	if rv.markdown.format == FormatTable {
+		return fmt.Errorf("regenerating docs is only supported for the document format")
+	}
+
+	content := rv.markdown.GetContent()
+	updated, err := writeDocs(ctx, rv.fsys, rv.readmePath, content, rv.markdown, rv.terraform, rv.options.DryRun)
+	if err != nil {
+		return err
+	}
+	if updated != content {
+		rv.reload(updated)
+	}
+	return nil
+}
+
+func (rv *ReadmeValidator) reload(content string) {
+	rv.markdown = NewMarkdownContentWithLevels(content, rv.markdown.format, rv.options.ProviderPrefixes, rv.markdown.sectionLevel, rv.markdown.itemLevel)
+	rv.validators = buildDefaultValidators(rv.fsys, rv.readmePath, rv.modulePath, rv.markdown, rv.terraform, rv.urlCache, rv.options)
+}
+
+func (rv *ReadmeValidator) applyBaseline(errs []error) ([]error, error) {
+	baseline, err := LoadBaseline(rv.options.BaselinePath)
+	if err != nil {
+		return errs, err
+	}
+
+	module := baselineModuleKey(rv.options.BaselinePath, rv.moduleName)
+	if !rv.options.UpdateBaseline {
+		return baseline.Filter(module, errs), nil
+	}
+
+	baseline.Update(module, errs)
+	if err := baseline.Save(rv.options.BaselinePath); err != nil {
+		return errs, err
+	}
+	if os.Getenv("VERBOSE") == "true" {
+		fmt.Printf("Recorded %d findings for %s in baseline %s\n", len(errs), module, rv.options.BaselinePath)
+	}
+	return nil, nil
+}
+
+func (rv *ReadmeValidator) SkippedURLs() []string {
+	return append([]string(nil), rv.skipped...)
 }
 
 func (rv *ReadmeValidator) GetFormat() MarkdownFormat {